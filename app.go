@@ -1,10 +1,32 @@
 package main
 
 import (
+	"net/url"
 	"runtime"
 	"strings"
 )
 
+// categoryOther holds apps that do not specify a category.
+const categoryOther = "other"
+
+// categoryAliases maps alternative spellings of a category onto the name used in the catalog.
+var categoryAliases = map[string]string{
+	"utilities":         "utility",
+	"util":              "utility",
+	"tool":              "tools",
+	"game":              "games",
+	"science":           "scientific",
+	"medicine":          "medical",
+	"educational":       "education",
+	"networking":        "network",
+	"developer":         "development",
+	"developer tools":   "development",
+	"development tools": "development",
+	"dev tools":         "development",
+	"misc":              categoryOther,
+	"miscellaneous":     categoryOther,
+}
+
 func (l AppList) filterCompatible() AppList {
 	ret := make(AppList, 0)
 	for _, v := range l {
@@ -15,6 +37,36 @@ func (l AppList) filterCompatible() AppList {
 	return ret
 }
 
+// search returns the apps that match a query and, if set, were published by the developer.
+func (l AppList) search(query, developer string) AppList {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" && developer == "" {
+		return l
+	}
+
+	ret := make(AppList)
+	for id, a := range l {
+		if developer != "" && !sameDeveloper(a.Developer, developer) {
+			continue
+		}
+		if query != "" && !a.matches(query) {
+			continue
+		}
+		ret[id] = a
+	}
+	return ret
+}
+
+func (a App) matches(query string) bool {
+	for _, field := range []string{a.Name, a.Summary, a.Developer, a.ID} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a App) isCompatible() bool {
 	return a.isCompatibleWithOS(runtime.GOOS)
 }
@@ -33,3 +85,53 @@ func (a App) isCompatibleWithOS(os string) bool {
 
 	return false
 }
+
+// resolveLinks returns a copy of the app with links made absolute, relative to the catalog source address.
+func (a App) resolveLinks(source *url.URL) App {
+	a.Icon = resolveURL(source, a.Icon)
+	a.URL = resolveURL(source, a.URL)
+	a.Website = resolveURL(source, a.Website)
+
+	shots := make([]AppScreenshot, len(a.Screenshots))
+	for i, s := range a.Screenshots {
+		s.Image = resolveURL(source, s.Image)
+		shots[i] = s
+	}
+	a.Screenshots = shots
+	releases := make([]AppRelease, len(a.Releases))
+	for i, r := range a.Releases {
+		r.URL = resolveURL(source, r.URL)
+		releases[i] = r
+	}
+	a.Releases = releases
+	return a
+}
+
+func resolveURL(source *url.URL, link string) string {
+	if link == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(link)
+	if err != nil {
+		return link // reported when the entry is validated
+	}
+	return source.ResolveReference(ref).String()
+}
+
+// category returns the normalised category of the app, so that similar names are grouped.
+func (a App) category() string {
+	return normalizeCategory(a.Category)
+}
+
+func normalizeCategory(cat string) string {
+	cat = strings.Join(strings.Fields(strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(cat))), " ")
+	if cat == "" {
+		return categoryOther
+	}
+
+	if alias, ok := categoryAliases[cat]; ok {
+		return alias
+	}
+	return cat
+}
@@ -42,3 +42,13 @@ func TestApp_IsCompatibleWithOS(t *testing.T) {
 	a.Requires = ""
 	assert.True(t, a.isCompatibleWithOS("powerpc"))
 }
+
+func TestNormalizeCategory(t *testing.T) {
+	assert.Equal(t, "utility", normalizeCategory("utility"))
+	assert.Equal(t, "utility", normalizeCategory(" Utilities "))
+	assert.Equal(t, "games", normalizeCategory("Game"))
+	assert.Equal(t, "development", normalizeCategory("Developer_Tools"))
+	assert.Equal(t, "graphics", normalizeCategory("GRAPHICS"))
+	assert.Equal(t, "other", normalizeCategory(""))
+	assert.Equal(t, "other", normalizeCategory("  "))
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	keySortOrder = "browse.sort"
+	keyViewMode  = "browse.view"
+
+	sortName      = "name"
+	sortNewest    = "newest"
+	sortDeveloper = "developer"
+	sortUpdated   = "updated"
+	sortInstalled = "installed"
+
+	viewList = "list"
+	viewGrid = "grid"
+
+	nodeFavorites = "@favorites"
+	nodeInstalled = "@installed"
+	nodeUpdates   = "@updates"
+	nodeRecent    = "@recent"
+
+	// recentDays is how long an app is listed in the recently added branch after its date
+	recentDays = 30
+)
+
+var virtualNodes = []string{nodeFavorites, nodeInstalled, nodeUpdates, nodeRecent}
+
+var virtualNames = map[string]string{
+	nodeFavorites: "Favorites",
+	nodeInstalled: "Installed",
+	nodeUpdates:   "Updates available",
+	nodeRecent:    "Recently added",
+}
+
+var sortOrders = []string{sortName, sortNewest, sortDeveloper, sortUpdated, sortInstalled}
+
+var sortNames = map[string]string{
+	sortName:      "Name",
+	sortNewest:    "Newest",
+	sortDeveloper: "Developer",
+	sortUpdated:   "Recently updated",
+	sortInstalled: "Installed first",
+}
+
+// sortApps orders app IDs for display. Apps that are equal in the chosen order are sorted by name.
+// Recently updated uses the time that each app was last installed or upgraded here.
+func sortApps(ids []string, list AppList, order string) {
+	var updated map[string]time.Time
+	if order == sortUpdated {
+		updated = lastInstallTimes()
+	}
+	byName := func(i, j int) bool {
+		return strings.Compare(strings.ToLower(list[ids[i]].Name), strings.ToLower(list[ids[j]].Name)) < 0
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, b := list[ids[i]], list[ids[j]]
+		switch order {
+		case sortNewest:
+			if !a.Date.Equal(b.Date) {
+				return a.Date.After(b.Date)
+			}
+		case sortDeveloper:
+			if devA, devB := strings.ToLower(a.Developer), strings.ToLower(b.Developer); devA != devB {
+				return devA < devB
+			}
+		case sortUpdated:
+			if timeA, timeB := updated[a.ID], updated[b.ID]; !timeA.Equal(timeB) {
+				return timeA.After(timeB)
+			}
+		case sortInstalled:
+			if instA, instB := installedVersion(a) != "", installedVersion(b) != ""; instA != instB {
+				return instA
+			}
+		}
+
+		return byName(i, j)
+	})
+}
+
+func lastInstallTimes() map[string]time.Time {
+	ret := make(map[string]time.Time)
+	for _, h := range loadHistory() {
+		if h.Error == "" && h.Date.After(ret[h.AppID]) {
+			ret[h.AppID] = h.Date
+		}
+	}
+	return ret
+}
+
+// mapVirtualNodes returns the branches that group apps by state rather than category.
+// The node IDs of apps inside them are prefixed by the branch, as each ID in a tree must be unique.
+func mapVirtualNodes(list AppList, order string, now time.Time) map[string][]string {
+	ret := make(map[string][]string)
+	var favorites, installed, updates, recent []string
+	for id, a := range list {
+		if isFavorite(a) {
+			favorites = append(favorites, id)
+		}
+		if installedVersion(a) != "" {
+			installed = append(installed, id)
+		}
+		if hasUpdate(a) {
+			updates = append(updates, id)
+		}
+		if a.Date.After(now.AddDate(0, 0, -recentDays)) {
+			recent = append(recent, id)
+		}
+	}
+
+	sortApps(favorites, list, order)
+	sortApps(installed, list, order)
+	sortApps(updates, list, order)
+	sortApps(recent, list, sortNewest)
+	for node, ids := range map[string][]string{nodeFavorites: favorites, nodeInstalled: installed,
+		nodeUpdates: updates, nodeRecent: recent} {
+		children := make([]string, len(ids))
+		for i, id := range ids {
+			children[i] = node + "/" + id
+		}
+		ret[node] = children
+	}
+	return ret
+}
+
+// nodeAppID returns the app ID for a tree node, removing any virtual branch prefix.
+func nodeAppID(node string) string {
+	if strings.HasPrefix(node, "@") {
+		if pos := strings.Index(node, "/"); pos != -1 {
+			return node[pos+1:]
+		}
+	}
+
+	return node
+}
+
+// visibleApps returns the apps matching the current search and developer filter.
+func (w *welcome) visibleApps() AppList {
+	return w.apps.search(w.query, w.filterBy)
+}
+
+// refreshNodes rebuilds the tree content from the visible apps.
+func (w *welcome) refreshNodes() {
+	w.nodes = mapAppList(w.visibleApps(), w.order)
+	w.updateVirtualNodes()
+	if w.tree != nil && (w.query != "" || w.filterBy != "") {
+		w.tree.OpenAllBranches()
+	}
+}
+
+// updateVirtualNodes refreshes the state based branches, without rebuilding the category nodes.
+func (w *welcome) updateVirtualNodes() {
+	for node, ids := range mapVirtualNodes(w.visibleApps(), w.order, time.Now()) {
+		w.nodes[node] = ids
+	}
+
+	root := []string{w.nodes[""][0]}
+	root = append(root, virtualNodes...)
+	for _, n := range w.nodes[""][1:] {
+		if _, ok := virtualNames[n]; !ok {
+			root = append(root, n)
+		}
+	}
+	w.nodes[""] = root
+	if w.tree != nil {
+		w.tree.Refresh()
+	}
+}
+
+func (w *welcome) makeTree() *widget.Tree {
+	w.tree = widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID {
+			return w.nodes[id]
+		},
+		w.isBranch,
+		func(_ bool) fyne.CanvasObject {
+			return container.NewHBox(widget.NewIcon(nil), widget.NewLabel(" ->  A longish app name"))
+		},
+		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			icon, label := treeItemParts(obj)
+			if id == "featured" || branch {
+				icon.Hide()
+				label.Importance = widget.MediumImportance
+				title := "Featured"
+				if name, ok := virtualNames[id]; ok {
+					title = name
+				} else if branch {
+					title = strings.ToUpper(id[:1]) + id[1:]
+				}
+
+				label.SetText(title)
+				return
+			}
+
+			a := w.apps[nodeAppID(id)]
+			icon.SetResource(trustIcon(a.trustLevel()))
+			icon.Show()
+			label.Importance = widget.MediumImportance
+			if w.added[a.ID] {
+				label.Importance = widget.HighImportance
+			}
+			label.SetText(a.Name)
+		})
+	w.tree.Select("featured")
+	w.tree.OnSelected = func(id widget.TreeNodeID) {
+		if id == "featured" {
+			w.showContent(w.featured)
+			return
+		}
+
+		if w.isBranch(id) {
+			w.tree.OpenBranch(id)
+			if w.view == viewGrid {
+				w.showCategory(id)
+			}
+			return
+		}
+
+		w.showApp(nodeAppID(id))
+	}
+
+	return w.tree
+}
+
+func (w *welcome) isBranch(id widget.TreeNodeID) bool {
+	if id == "" {
+		return true
+	}
+	if id == "featured" {
+		return false
+	}
+
+	for _, n := range w.nodes[""] {
+		if n == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func treeItemParts(obj fyne.CanvasObject) (*widget.Icon, *widget.Label) {
+	parts := obj.(*fyne.Container).Objects
+	return parts[0].(*widget.Icon), parts[1].(*widget.Label)
+}
+
+// selectApp reveals an app in its category and shows the details.
+func (w *welcome) selectApp(id string) {
+	w.tree.OpenBranch(w.apps[id].category())
+	w.tree.Select(id)
+	w.showApp(id)
+}
+
+func (w *welcome) showApp(id string) {
+	w.showContent(w.detail)
+	w.loadAppDetail(w.apps[id])
+}
+
+// showCategory displays the apps in a category as a grid of cards.
+func (w *welcome) showCategory(id string) {
+	w.category = id
+	ids := make([]string, len(w.nodes[id]))
+	for i, node := range w.nodes[id] {
+		ids[i] = nodeAppID(node)
+	}
+	w.grid.Objects = []fyne.CanvasObject{container.NewVScroll(makeAppGrid(ids, w.apps, w.selectApp))}
+	w.grid.Refresh()
+	w.showContent(w.grid)
+}
+
+func (w *welcome) showContent(obj fyne.CanvasObject) {
+	for _, o := range []fyne.CanvasObject{w.featured, w.detail, w.grid, w.devPage} {
+		if o == obj {
+			o.Show()
+		} else {
+			o.Hide()
+		}
+	}
+}
+
+func (w *welcome) setSortOrder(order string) {
+	w.order = order
+	fyne.CurrentApp().Preferences().SetString(keySortOrder, order)
+
+	w.refreshNodes()
+	if w.grid.Visible() {
+		w.showCategory(w.category)
+	}
+}
+
+func (w *welcome) setViewMode(view string) {
+	w.view = view
+	fyne.CurrentApp().Preferences().SetString(keyViewMode, view)
+
+	if view == viewList && w.grid.Visible() {
+		w.showContent(w.featured)
+		w.tree.Select("featured")
+	}
+}
+
+func (w *welcome) setFilter(query, developer string) {
+	w.query, w.filterBy = query, developer
+	w.refreshNodes()
+	if w.grid.Visible() {
+		w.showCategory(w.category)
+	}
+}
+
+// makeBrowseControls returns the search, filter, sort, refresh and view mode choices shown above the app tree.
+func (w *welcome) makeBrowseControls(win fyne.Window) fyne.CanvasObject {
+	w.search = widget.NewEntry()
+	w.search.SetPlaceHolder("Search apps")
+	w.search.OnChanged = func(query string) {
+		w.setFilter(query, w.filterBy)
+	}
+	w.developerFilter = widget.NewSelect(append([]string{allDevelopers}, w.apps.developers()...), func(dev string) {
+		if dev == allDevelopers {
+			dev = ""
+		}
+		w.setFilter(w.query, dev)
+	})
+	w.developerFilter.SetSelected(allDevelopers)
+
+	names := make([]string, len(sortOrders))
+	for i, order := range sortOrders {
+		names[i] = sortNames[order]
+	}
+	order := widget.NewSelect(names, func(name string) {
+		for _, o := range sortOrders {
+			if sortNames[o] == name && o != w.order {
+				w.setSortOrder(o)
+			}
+		}
+	})
+	order.SetSelected(sortNames[w.order])
+
+	views := widget.NewToolbar(
+		widget.NewToolbarAction(theme.ViewRefreshIcon(), func() {
+			w.refresh(win)
+		}),
+		widget.NewToolbarAction(theme.ListIcon(), func() {
+			w.setViewMode(viewList)
+		}),
+		widget.NewToolbarAction(theme.GridIcon(), func() {
+			w.setViewMode(viewGrid)
+		}))
+	return container.NewVBox(w.search, w.developerFilter, container.NewBorder(nil, nil, nil, views, order))
+}
+
+func makeAppGrid(ids []string, apps AppList, choose func(string)) fyne.CanvasObject {
+	cards := make([]fyne.CanvasObject, len(ids))
+	for i, id := range ids {
+		a := apps[id]
+		icon := &canvas.Image{FillMode: canvas.ImageFillContain}
+		icon.SetMinSize(fyne.NewSquareSize(64))
+		go setImageFromURL(icon, a.Icon)
+
+		name := widget.NewLabel(a.Name)
+		name.Alignment = fyne.TextAlignCenter
+		name.Truncation = fyne.TextTruncateEllipsis
+		tap := widget.NewButton("", func() {
+			choose(id)
+		})
+		tap.Importance = widget.LowImportance
+
+		cards[i] = container.NewStack(tap, container.NewBorder(nil, name, nil, nil, icon))
+	}
+
+	return container.NewGridWrap(fyne.NewSize(140, 120), cards...)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSortList = AppList{
+	"a": App{ID: "a", Name: "bugs", Developer: "The Fyne Team", Date: time.Date(2018, 10, 18, 0, 0, 0, 0, time.UTC)},
+	"b": App{ID: "b", Name: "Calculator", Developer: "The Fyne Team", Date: time.Date(2018, 10, 4, 0, 0, 0, 0, time.UTC)},
+	"c": App{ID: "c", Name: "BeebUI", Developer: "Andy Williams", Date: time.Date(2019, 3, 17, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestSortApps(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	sortApps(ids, testSortList, sortName)
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+
+	sortApps(ids, testSortList, sortNewest)
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+
+	ids = []string{"b", "a", "c"}
+	sortApps(ids, testSortList, sortDeveloper)
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+}
+
+func TestMapAppList(t *testing.T) {
+	list := AppList{
+		"a": App{ID: "a", Name: "Bugs", Category: "games"},
+		"b": App{ID: "b", Name: "Abacus", Category: "utility"},
+		"c": App{ID: "c", Name: "Asteroids", Category: "Game"},
+		"d": App{ID: "d", Name: "Zebra"},
+		"e": App{ID: "e", Name: "Calculator", Category: "Utilities"},
+	}
+
+	nodes := mapAppList(list, sortName)
+	assert.Equal(t, []string{"featured", "games", "utility", "other"}, nodes[""])
+	assert.Equal(t, []string{"c", "a"}, nodes["games"])
+	assert.Equal(t, []string{"b", "e"}, nodes["utility"])
+	assert.Equal(t, []string{"d"}, nodes["other"])
+}
+
+func TestMapVirtualNodes(t *testing.T) {
+	test.NewTempApp(t)
+	now := time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC)
+	list := AppList{
+		"a": App{ID: "a", Name: "bugs", Version: "1.1"},
+		"b": App{ID: "b", Name: "Calculator", Version: "1.0"},
+		"c": App{ID: "c", Name: "BeebUI", Date: time.Date(2019, 3, 17, 0, 0, 0, 0, time.UTC)},
+		"d": App{ID: "d", Name: "Dice", Version: "2.0"},
+		"e": App{ID: "e", Name: "Editor", Version: "1.0"},
+	}
+	setInstalledVersion(list["a"], "1.0")
+	markInstalled(list["b"])
+	setInstalledVersion(list["d"], versionUnknown)
+	setInstalledVersion(list["e"], versionLatest)
+
+	nodes := mapVirtualNodes(list, sortName, now)
+	assert.Equal(t, []string{nodeInstalled + "/a", nodeInstalled + "/b", nodeInstalled + "/d", nodeInstalled + "/e"},
+		nodes[nodeInstalled])
+	assert.Equal(t, []string{nodeUpdates + "/a"}, nodes[nodeUpdates])
+	assert.Equal(t, []string{nodeRecent + "/c"}, nodes[nodeRecent])
+}
+
+func TestNodeAppID(t *testing.T) {
+	assert.Equal(t, "xyz.andy.beebui", nodeAppID("xyz.andy.beebui"))
+	assert.Equal(t, "xyz.andy.beebui", nodeAppID(nodeInstalled+"/xyz.andy.beebui"))
+	assert.Equal(t, "featured", nodeAppID("featured"))
+}
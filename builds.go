@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// maxStoredBuilds is how many builds of each app are kept for rolling back.
+const maxStoredBuilds = 3
+
+type storedBuild struct {
+	Version string
+	Date    time.Time
+
+	path string
+}
+
+func buildStoreDir(a App) string {
+	return filepath.Join(fyne.CurrentApp().Storage().RootURI().Path(), "builds", a.ID)
+}
+
+// storeBuild keeps a copy of the currently installed version of an app so it can be restored later.
+func storeBuild(a App) error {
+	src := installedPath(a)
+	if src == "" {
+		return errors.New("could not find installed app " + a.ID)
+	}
+
+	return storeBuildIn(buildStoreDir(a), src, installedVersion(a), time.Now())
+}
+
+// storedBuilds returns the builds available for an app, newest first.
+func storedBuilds(a App) []storedBuild {
+	return storedBuildsIn(buildStoreDir(a))
+}
+
+// previousBuilds returns the stored builds that could replace the currently installed version.
+func previousBuilds(a App) []storedBuild {
+	return buildsExcept(storedBuilds(a), installedVersion(a))
+}
+
+func buildsExcept(builds []storedBuild, ver string) []storedBuild {
+	var ret []storedBuild
+	for _, b := range builds {
+		if b.Version != ver {
+			ret = append(ret, b)
+		}
+	}
+	return ret
+}
+
+// restoreBuild reinstates a stored build over the installed app, without recompiling.
+func restoreBuild(a App, b storedBuild) (err error) {
+	start := time.Now()
+	defer func() {
+		restored := a
+		restored.Version = b.Version
+		recordInstall(restored, "rollback", b.path, "", start, err)
+	}()
+
+	dest := installedPath(a)
+	if dest == "" {
+		dest = installCandidates(a)[0]
+	}
+
+	err = replacePath(b.path, dest)
+	if err != nil {
+		return err
+	}
+
+	setInstalledVersion(a, b.Version)
+	return touchBuild(b)
+}
+
+// replacePath copies src over dst, keeping the old dst until the new copy is in place.
+func replacePath(src, dst string) error {
+	err := os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), ".restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	staged := filepath.Join(tmp, "new")
+	err = copyPath(src, staged)
+	if err != nil {
+		return err
+	}
+
+	old := filepath.Join(tmp, "old")
+	err = os.Rename(dst, old)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	hadOld := err == nil
+
+	err = os.Rename(staged, dst)
+	if err != nil && hadOld {
+		_ = os.Rename(old, dst)
+	}
+	return err
+}
+
+// makeBuildDir creates a uniquely named folder to store a build in, the name records the date and version.
+func makeBuildDir(dir, ver string, date time.Time) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	return os.MkdirTemp(dir, strconv.FormatInt(date.UnixNano(), 10)+"-*_"+ver)
+}
+
+func storeBuildIn(dir, src, ver string, date time.Time) error {
+	build, err := makeBuildDir(dir, ver, date)
+	if err != nil {
+		return err
+	}
+	err = copyPath(src, filepath.Join(build, filepath.Base(src)))
+	if err != nil {
+		_ = os.RemoveAll(build)
+		return err
+	}
+
+	builds := storedBuildsIn(dir)
+	for i := maxStoredBuilds; i < len(builds); i++ {
+		_ = os.RemoveAll(filepath.Dir(builds[i].path))
+	}
+	return nil
+}
+
+func storedBuildsIn(dir string) []storedBuild {
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var builds []storedBuild
+	for _, item := range items {
+		parts := strings.SplitN(item.Name(), "_", 2)
+		if !item.IsDir() || len(parts) != 2 {
+			continue
+		}
+		stamp, err := strconv.ParseInt(strings.SplitN(parts[0], "-", 2)[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		content, err := os.ReadDir(filepath.Join(dir, item.Name()))
+		if err != nil || len(content) != 1 {
+			continue
+		}
+		builds = append(builds, storedBuild{Version: parts[1], Date: time.Unix(0, stamp),
+			path: filepath.Join(dir, item.Name(), content[0].Name())})
+	}
+
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].Date.After(builds[j].Date)
+	})
+	return builds
+}
+
+// touchBuild moves a restored build to the front of the store so it is seen as current.
+func touchBuild(b storedBuild) error {
+	dir := filepath.Dir(b.path)
+	build, err := makeBuildDir(filepath.Dir(dir), b.Version, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(b.path, filepath.Join(build, filepath.Base(b.path)))
+	if err != nil {
+		_ = os.RemoveAll(build)
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (b storedBuild) String() string {
+	return fmt.Sprintf("%s (%s)", b.Version, b.Date.Format("02 Jan 2006 15:04"))
+}
+
+func copyPath(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, dst)
+	case info.IsDir():
+		err = os.MkdirAll(dst, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		items, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err = copyPath(filepath.Join(src, item.Name()), filepath.Join(dst, item.Name()))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), 0755)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
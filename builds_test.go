@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreBuildIn(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "app")
+	err := os.WriteFile(src, []byte("v1"), 0755)
+	assert.Nil(t, err)
+
+	store := t.TempDir()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, ver := range []string{"1.0", "1.1", "1.2", "2.0"} {
+		err = storeBuildIn(store, src, ver, start.Add(time.Duration(i)*time.Hour))
+		assert.Nil(t, err)
+	}
+
+	builds := storedBuildsIn(store)
+	assert.Equal(t, maxStoredBuilds, len(builds))
+	assert.Equal(t, "2.0", builds[0].Version)
+	assert.Equal(t, "1.1", builds[2].Version)
+
+	data, err := os.ReadFile(builds[0].path)
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", string(data))
+}
+
+func TestStoreBuildIn_SameTime(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "app")
+	err := os.WriteFile(src, []byte("v1"), 0755)
+	assert.Nil(t, err)
+
+	store := t.TempDir()
+	now := time.Now()
+	assert.Nil(t, storeBuildIn(store, src, "1.0", now))
+	assert.Nil(t, storeBuildIn(store, src, "1.0", now))
+	assert.Equal(t, 2, len(storedBuildsIn(store)))
+}
+
+func TestBuildsExcept(t *testing.T) {
+	builds := []storedBuild{{Version: "1.1"}, {Version: "1.0"}}
+	assert.Equal(t, []storedBuild{{Version: "1.0"}}, buildsExcept(builds, "1.1"))
+	assert.Equal(t, builds, buildsExcept(builds, "2.0"))
+}
+
+func TestReplacePath(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "stored"), filepath.Join(dir, "app")
+	assert.Nil(t, os.WriteFile(src, []byte("old"), 0755))
+	assert.Nil(t, os.WriteFile(dst, []byte("new"), 0755))
+
+	assert.Nil(t, replacePath(src, dst))
+	data, err := os.ReadFile(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "old", string(data))
+
+	assert.NotNil(t, replacePath(filepath.Join(dir, "missing"), dst))
+	data, err = os.ReadFile(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "old", string(data))
+
+	items, _ := os.ReadDir(dir)
+	assert.Equal(t, 2, len(items))
+}
+
+func TestCopyPath(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "Test.app")
+	err := os.MkdirAll(filepath.Join(src, "Contents", "MacOS"), 0755)
+	assert.Nil(t, err)
+	err = os.WriteFile(filepath.Join(src, "Contents", "MacOS", "test"), []byte("exe"), 0755)
+	assert.Nil(t, err)
+
+	dst := filepath.Join(t.TempDir(), "Copy.app")
+	err = copyPath(src, dst)
+	assert.Nil(t, err)
+
+	info, err := os.Stat(filepath.Join(dst, "Contents", "MacOS", "test"))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
+	"errors"
 	"io"
-	"net/http"
+	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -11,27 +13,54 @@ import (
 	"fyne.io/fyne/v2"
 )
 
-const keyInstallPrefix = "installed."
+const (
+	keyInstallPrefix  = "installed."
+	keyFavoritePrefix = "favorite."
+	keyCacheSource    = "cache.source"
+)
 
 type App struct {
-	ID, Name, Icon         string
-	Developer, Summary     string
-	URL, Website, Category string
-	Screenshots            []AppScreenshot
-
-	Date    time.Time
-	Version string
-
-	Source   AppSource
-	Requires string
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Icon        string          `json:"icon"`
+	Developer   string          `json:"developer"`
+	Summary     string          `json:"summary"`
+	URL         string          `json:"url"`
+	Website     string          `json:"website"`
+	Category    string          `json:"category"`
+	Screenshots []AppScreenshot `json:"screenshots,omitempty"`
+
+	Date    time.Time `json:"date"`
+	Version string    `json:"version"`
+
+	Source   AppSource    `json:"source"`
+	Releases []AppRelease `json:"releases,omitempty"`
+	Requires string       `json:"requires,omitempty"`
+	Trust    string       `json:"trust,omitempty"`
 }
 
 type AppScreenshot struct {
-	Image, Type string
+	Image string `json:"image"`
+	Type  string `json:"type"`
 }
 
 type AppSource struct {
-	Git, Package string
+	Git     string `json:"git"`
+	Package string `json:"package"`
+
+	// Optional pins that the fetched source must match before it is built
+	Version string `json:"version,omitempty"`
+	Commit  string `json:"commit,omitempty"`
+	Sum     string `json:"sum,omitempty"`
+}
+
+// AppRelease describes a prebuilt package of an app for a specific platform.
+type AppRelease struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256"`
 }
 
 type AppList map[string]App
@@ -40,46 +69,136 @@ func installedVersion(a App) string {
 	return fyne.CurrentApp().Preferences().String(keyInstallPrefix + a.ID)
 }
 
+// versionLatest is stored for apps installed from a catalog entry that has no version.
+const versionLatest = "latest"
+
+// hasUpdate returns true if an app is installed and the catalog has a different version.
+// Installs without a known version cannot be compared so are never reported as out of date.
+func hasUpdate(a App) bool {
+	switch installed := installedVersion(a); installed {
+	case "", versionUnknown, versionLatest:
+		return false
+	default:
+		return a.Version != "" && installed != a.Version
+	}
+}
+
 func markInstalled(a App) {
 	ver := a.Version
 	if ver == "" {
-		ver = "latest"
+		ver = versionLatest
 	}
+	setInstalledVersion(a, ver)
+}
+
+func setInstalledVersion(a App, ver string) {
 	fyne.CurrentApp().Preferences().SetString(keyInstallPrefix+a.ID, ver)
 }
 
+// parseAppList reads the catalog, leaving out any entries that are not valid.
 func parseAppList(reader io.Reader) (AppList, error) {
-	decode := json.NewDecoder(reader)
+	list, _, err := parseAppListWithWarnings(reader, nil)
+	return list, err
+}
+
+// catalog is a loaded app list along with the problems found in it and where it came from.
+type catalog struct {
+	apps     AppList
+	warnings []catalogWarning
+
+	// all includes the apps that are not available for this platform
+	all AppList
+
+	// source is the address that the list was downloaded from, or empty if it was read from the cache
+	source string
+}
+
+// fetchAppList loads the catalog from the web, trying each mirror in turn, and falls back to the last
+// copy downloaded if that fails. If the web catalog is too new to read then the cached list is
+// returned along with a *schemaError.
+func fetchAppList() (catalog, error) {
+	var cat catalog
+	raw, source, err := downloadAppList()
+	if err == nil {
+		cat.all, cat.warnings, err = parseCatalog(bytes.NewReader(raw), source)
+		if err == nil {
+			limitTrust(cat.all, source)
+			cat.apps = cat.all.filterCompatible()
+			saveAppListCache(raw, source)
+			cat.source = source.String()
+			return cat, nil
+		}
+	}
 
-	var list []App
-	err := decode.Decode(&list)
+	log.Println("Web failed, reading cache:", err)
+	webErr := err
+	source, err = url.Parse(cacheSource())
 	if err != nil {
-		return nil, err
+		return cat, err
+	}
+	data, err := loadAppListFromCache()
+	if err == nil {
+		defer data.Close()
+		cat.all, cat.warnings, err = parseCatalog(data, source)
+		if err == nil {
+			limitTrust(cat.all, source)
+			cat.apps = cat.all.filterCompatible()
+		}
 	}
 
-	appList := AppList{}
-	for _, a := range list {
-		appList[a.ID] = a
+	var tooNew *schemaError
+	if errors.As(webErr, &tooNew) {
+		return cat, webErr
+	}
+	return cat, err
+}
+
+// downloadAppList returns the catalog data and the address that it was loaded from.
+func downloadAppList() ([]byte, *url.URL, error) {
+	data, source, err := loadAppListFromWeb()
+	if err != nil {
+		return nil, nil, err
 	}
+	defer data.Close()
 
-	return appList.filterCompatible(), nil
+	raw, err := io.ReadAll(data)
+	return raw, source, err
 }
 
-func loadAppListFromWeb() (io.ReadCloser, error) {
-	res, err := http.Get("https://apps.fyne.io/api/v1/list.json")
+func loadAppListFromWeb() (io.ReadCloser, *url.URL, error) {
+	res, base, err := getFromMirrors(apiPath("list.json"))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return res.Body, nil
+	source, err := url.Parse(catalogURL(base))
+	if err != nil {
+		res.Body.Close()
+		return nil, nil, err
+	}
+	return res.Body, source, nil
+}
+
+func appListCachePath() string {
+	return filepath.Join(fyne.CurrentApp().Storage().RootURI().Path(), "list.json")
 }
 
-// TODO make actual cache read()
 func loadAppListFromCache() (io.ReadCloser, error) {
-	res, err := os.Open(filepath.Join("testdata", "list.json"))
+	return os.Open(appListCachePath())
+}
+
+// saveAppListCache keeps a copy of the catalog along with the address it was downloaded from,
+// which may be a mirror, so that relative links in it can be resolved when offline.
+func saveAppListCache(data []byte, source *url.URL) {
+	err := os.WriteFile(appListCachePath(), data, 0600)
 	if err != nil {
-		return nil, err
+		fyne.LogError("Failed to cache app list", err)
+		return
 	}
+	fyne.CurrentApp().Preferences().SetString(keyCacheSource, source.String())
+}
 
-	return res, nil
+// cacheSource returns the address that the cached catalog was downloaded from.
+func cacheSource() string {
+	return fyne.CurrentApp().Preferences().StringWithFallback(keyCacheSource, catalogURL(catalogBase()))
 }
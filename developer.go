@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// allDevelopers is the developer filter option that shows every app.
+const allDevelopers = "All developers"
+
+func sameDeveloper(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// developers returns the names of everyone who has published an app in the list.
+func (l AppList) developers() []string {
+	var ret []string
+	for _, a := range l {
+		if a.Developer == "" {
+			continue
+		}
+
+		found := false
+		for _, d := range ret {
+			if sameDeveloper(d, a.Developer) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, strings.TrimSpace(a.Developer))
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i]) < strings.ToLower(ret[j])
+	})
+	return ret
+}
+
+// developerApps returns the IDs of apps by a developer, newest first.
+func (l AppList) developerApps(dev string) []string {
+	var ids []string
+	for id, a := range l {
+		if sameDeveloper(a.Developer, dev) {
+			ids = append(ids, id)
+		}
+	}
+
+	sortApps(ids, l, sortNewest)
+	return ids
+}
+
+// developerWebsites returns the distinct websites of a developer's apps.
+func (l AppList) developerWebsites(ids []string) []string {
+	var sites []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		site := l[id].Website
+		if site == "" || seen[site] {
+			continue
+		}
+
+		seen[site] = true
+		sites = append(sites, site)
+	}
+	return sites
+}
+
+func developerSummary(list AppList, ids []string) string {
+	if len(ids) == 0 {
+		return "No apps in the catalog"
+	}
+
+	count := "1 app"
+	if len(ids) > 1 {
+		count = fmt.Sprintf("%d apps", len(ids))
+	}
+	latest := list[ids[0]]
+	return fmt.Sprintf("%s, latest release %s (%s)", count, latest.Name, latest.Date.Format("02 Jan 2006"))
+}
+
+// showDeveloper displays a page listing all of the apps published by a developer.
+func (w *welcome) showDeveloper(dev string) {
+	ids := w.apps.developerApps(dev)
+
+	name := widget.NewLabel(dev)
+	name.TextStyle.Bold = true
+	info := widget.NewLabel(developerSummary(w.apps, ids))
+	links := container.NewVBox()
+	for _, site := range w.apps.developerWebsites(ids) {
+		u, err := url.Parse(site)
+		if err != nil {
+			continue
+		}
+		links.Add(widget.NewHyperlink(u.Host+u.Path, u))
+	}
+	filter := widget.NewButton("Filter by developer", func() {
+		for _, option := range w.developerFilter.Options {
+			if sameDeveloper(option, dev) {
+				w.developerFilter.SetSelected(option)
+			}
+		}
+	})
+
+	header := container.NewVBox(container.NewBorder(nil, nil, nil, filter, name), info, links)
+	w.devPage.Objects = []fyne.CanvasObject{container.NewBorder(header, nil, nil, nil,
+		container.NewVScroll(makeAppGrid(ids, w.apps, w.selectApp)))}
+	w.devPage.Refresh()
+	w.showContent(w.devPage)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDeveloperList = AppList{
+	"a": App{ID: "a", Name: "Bugs", Developer: "The Fyne Team", Website: "https://github.com/fyne-io/examples/",
+		Date: time.Date(2018, 10, 18, 0, 0, 0, 0, time.UTC)},
+	"b": App{ID: "b", Name: "Calculator", Developer: "the fyne team ", Website: "https://github.com/fyne-io/examples/",
+		Date: time.Date(2018, 10, 4, 0, 0, 0, 0, time.UTC)},
+	"c": App{ID: "c", Name: "BeebUI", Developer: "Andy Williams", Website: "https://github.com/andydotxyz/beebui",
+		Date: time.Date(2019, 3, 17, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestAppList_Developers(t *testing.T) {
+	devs := testDeveloperList.developers()
+	assert.Equal(t, 2, len(devs))
+	assert.Equal(t, "Andy Williams", devs[0])
+}
+
+func TestAppList_DeveloperApps(t *testing.T) {
+	ids := testDeveloperList.developerApps("The Fyne Team")
+	assert.Equal(t, []string{"a", "b"}, ids)
+	assert.Equal(t, []string{"https://github.com/fyne-io/examples/"}, testDeveloperList.developerWebsites(ids))
+	assert.Equal(t, "2 apps, latest release Bugs (18 Oct 2018)", developerSummary(testDeveloperList, ids))
+
+	assert.Equal(t, "No apps in the catalog", developerSummary(testDeveloperList, nil))
+}
+
+func TestAppList_Search(t *testing.T) {
+	assert.Equal(t, 3, len(testDeveloperList.search("", "")))
+	assert.Equal(t, 1, len(testDeveloperList.search("calc", "")))
+	assert.Equal(t, 2, len(testDeveloperList.search("", "the fyne team")))
+	assert.Equal(t, 0, len(testDeveloperList.search("beeb", "The Fyne Team")))
+}
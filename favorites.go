@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+)
+
+var (
+	favoriteIcon = theme.NewThemedResource(&fyne.StaticResource{StaticName: "star.svg", StaticContent: []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M12 17.27L18.18 21l-1.64-7.03L22 9.24l-7.19-.61L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21z"/></svg>`)})
+	notFavoriteIcon = theme.NewThemedResource(&fyne.StaticResource{StaticName: "star_border.svg", StaticContent: []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M22 9.24l-7.19-.62L12 2 9.19 8.63 2 9.24l5.46 4.73L5.82 21 12 17.27 18.18 21l-1.63-7.03L22 9.24zM12 15.4l-3.76 2.27 1-4.28-3.32-2.88 4.38-.38L12 6.1l1.71 4.04 4.38.38-3.32 2.88 1 4.28L12 15.4z"/></svg>`)})
+)
+
+func isFavorite(a App) bool {
+	return fyne.CurrentApp().Preferences().Bool(keyFavoritePrefix + a.ID)
+}
+
+func setFavorite(a App, fav bool) {
+	if fav {
+		fyne.CurrentApp().Preferences().SetBool(keyFavoritePrefix+a.ID, true)
+	} else {
+		fyne.CurrentApp().Preferences().RemoveValue(keyFavoritePrefix + a.ID)
+	}
+}
+
+// favorites returns the IDs of all apps in the list that the user has starred.
+func (l AppList) favorites() []string {
+	var ids []string
+	for id, a := range l {
+		if isFavorite(a) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+func readFavorites(r io.Reader) ([]string, error) {
+	var ids []string
+	err := json.NewDecoder(r).Decode(&ids)
+	return ids, err
+}
+
+func writeFavorites(w io.Writer, ids []string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ids)
+}
+
+func (w *welcome) toggleFavorite() {
+	setFavorite(w.shownApp, !isFavorite(w.shownApp))
+	w.updateFavorite()
+	w.updateVirtualNodes()
+}
+
+func (w *welcome) updateFavorite() {
+	if isFavorite(w.shownApp) {
+		w.favorite.SetIcon(favoriteIcon)
+	} else {
+		w.favorite.SetIcon(notFavoriteIcon)
+	}
+}
+
+func (w *welcome) exportFavorites(win fyne.Window) {
+	save := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil || f == nil {
+			return
+		}
+		defer f.Close()
+
+		err = writeFavorites(f, w.apps.favorites())
+		if err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	save.SetFileName("favorites.json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+// importFavorites adds the apps listed in a file to the favorites, skipping any not in the catalog.
+func (w *welcome) importFavorites(win fyne.Window) {
+	open := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+		if err != nil || f == nil {
+			return
+		}
+		defer f.Close()
+
+		ids, err := readFavorites(f)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		for _, id := range ids {
+			if a, ok := w.apps[id]; ok {
+				setFavorite(a, true)
+			}
+		}
+		w.updateFavorite()
+		w.updateVirtualNodes()
+	}, win)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavorites(t *testing.T) {
+	test.NewTempApp(t)
+	list := AppList{
+		"a": App{ID: "a", Name: "Bugs"},
+		"b": App{ID: "b", Name: "Calculator"},
+		"c": App{ID: "c", Name: "BeebUI"},
+	}
+	assert.Empty(t, list.favorites())
+
+	setFavorite(list["c"], true)
+	setFavorite(list["a"], true)
+	assert.True(t, isFavorite(list["a"]))
+	assert.Equal(t, []string{"a", "c"}, list.favorites())
+
+	nodes := mapVirtualNodes(list, sortName, time.Time{})
+	assert.Equal(t, []string{nodeFavorites + "/c", nodeFavorites + "/a"}, nodes[nodeFavorites])
+
+	setFavorite(list["a"], false)
+	assert.False(t, isFavorite(list["a"]))
+	assert.Equal(t, []string{"c"}, list.favorites())
+}
+
+func TestReadWriteFavorites(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.Nil(t, writeFavorites(buf, []string{"a", "c"}))
+
+	ids, err := readFavorites(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "c"}, ids)
+
+	_, err = readFavorites(bytes.NewBufferString("{}"))
+	assert.NotNil(t, err)
+}
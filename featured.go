@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"image/color"
 	"io"
-	"net/http"
+	"net/url"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
@@ -24,13 +23,18 @@ type Feature struct {
 func makeFeatured(apps AppList, choose func(string)) *fyne.Container {
 	featured := canvas.NewRectangle(theme.ErrorColor())
 	featured.SetMinSize(fyne.NewSquareSize(64))
-	res, err := http.Get("https://apps.fyne.io/api/v1/featured.json")
+	res, base, err := getFromMirrors(apiPath("featured.json"))
 	if err != nil {
 		// TODO handle this!
 		fyne.LogError("Failed to parse featured", err)
 		return &fyne.Container{}
 	}
 	defer res.Body.Close()
+	source, err := url.Parse(base + apiPath("featured.json"))
+	if err != nil {
+		fyne.LogError("Invalid catalog address", err)
+		return &fyne.Container{}
+	}
 
 	list, err := parseFeatured(res.Body)
 	if err != nil {
@@ -44,14 +48,8 @@ func makeFeatured(apps AppList, choose func(string)) *fyne.Container {
 
 		var obj fyne.CanvasObject
 		if item.Image != "" {
-			path := item.Image
-			if path[0] == '/' {
-				path = "https://apps.fyne.io" + path
-			}
-
-			u, _ := storage.ParseURI(path)
-			img := canvas.NewImageFromURI(u)
-			img.FillMode = canvas.ImageFillContain
+			img := &canvas.Image{FillMode: canvas.ImageFillContain}
+			go setImageFromURL(img, resolveURL(source, item.Image))
 
 			obj = img
 		} else {
@@ -61,14 +59,9 @@ func makeFeatured(apps AppList, choose func(string)) *fyne.Container {
 			fg.A = 0xff
 			_, err = fmt.Sscanf(item.Color, "#%02x%02x%02x", &fg.R, &fg.G, &fg.B)
 
-			path := item.Icon
-			if path[0] == '/' {
-				path = "https://apps.fyne.io" + path
-			}
-
-			u, _ := storage.ParseURI(path)
-			icon := canvas.NewImageFromURI(u)
+			icon := &canvas.Image{}
 			icon.SetMinSize(fyne.NewSquareSize(42))
+			go setImageFromURL(icon, resolveURL(source, item.Icon))
 
 			desc := item.Description
 			if len(desc) > 64 {
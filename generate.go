@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/mod/modfile"
+)
+
+// fyneAppMetadata holds the parts of a FyneApp.toml file that are used in a catalog entry.
+type fyneAppMetadata struct {
+	Website string `toml:"Website"`
+	Details struct {
+		Icon    string `toml:"Icon"`
+		Name    string `toml:"Name"`
+		ID      string `toml:"ID"`
+		Version string `toml:"Version"`
+	} `toml:"Details"`
+}
+
+// runGenerate prints a catalog entry for the project in a folder, ready to submit to the catalog.
+// It returns the exit code for the command.
+func runGenerate(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	a := App{}
+	flags.StringVar(&a.Summary, "summary", "", "a short description of the app")
+	flags.StringVar(&a.Category, "category", "", "the catalog category, one of "+strings.Join(knownCategories, ", "))
+	flags.StringVar(&a.Developer, "developer", "", "the developer name, defaults to the git user.name")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: apps generate [options] [folder]")
+		flags.PrintDefaults()
+	}
+	if flags.Parse(args) != nil {
+		return 2
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	entry, warnings, err := generateEntry(dir, a)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(entry)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// generateEntry fills in a catalog entry from the FyneApp.toml, go.mod and git remote of a project.
+// The summary, category and developer are kept from the app passed in, anything that could not be
+// worked out is reported in the returned warnings.
+func generateEntry(dir string, a App) (App, []string, error) {
+	var meta fyneAppMetadata
+	_, err := toml.DecodeFile(filepath.Join(dir, "FyneApp.toml"), &meta)
+	if err != nil {
+		return a, nil, err
+	}
+	if meta.Details.ID == "" || meta.Details.Name == "" {
+		return a, nil, errors.New("FyneApp.toml must set the app Name and ID")
+	}
+
+	a.ID, a.Name, a.Version = meta.Details.ID, meta.Details.Name, meta.Details.Version
+	a.Website = meta.Website
+	a.Date = time.Now().UTC().Truncate(time.Second)
+
+	var warnings []string
+	a.Source.Package, err = packagePath(dir)
+	if err != nil {
+		warnings = append(warnings, "could not find the package path: "+err.Error())
+	}
+
+	remote, err := gitOutput(dir, "remote", "get-url", "origin")
+	if err != nil {
+		warnings = append(warnings, "could not find the git remote, set source.git by hand")
+	} else {
+		a.Source.Git = gitWebURL(remote)
+	}
+	if a.Developer == "" {
+		a.Developer, _ = gitOutput(dir, "config", "user.name")
+	}
+
+	if meta.Details.Icon != "" {
+		a.Icon = iconURL(dir, a.Source.Git, meta.Details.Icon)
+		if a.Icon == "" {
+			warnings = append(warnings, "the icon must be a URL, set icon to where "+meta.Details.Icon+" is published")
+		}
+	}
+	if a.Website == "" {
+		a.Website = strings.TrimSuffix(a.Source.Git, ".git")
+	}
+	for _, w := range a.validate() {
+		warnings = append(warnings, w.Field+": "+w.Message)
+	}
+	return a, warnings, nil
+}
+
+// packagePath returns the import path of the package in a folder, from the enclosing go.mod.
+func packagePath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := abs; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			mod := modfile.ModulePath(data)
+			if mod == "" {
+				return "", errors.New("no module path in " + filepath.Join(root, "go.mod"))
+			}
+
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(mod, filepath.ToSlash(rel)), nil
+		}
+
+		if filepath.Dir(root) == root {
+			return "", errors.New("no go.mod found")
+		}
+	}
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// gitWebURL turns a git remote, which may be in the user@host:path form, into an https URL.
+func gitWebURL(remote string) string {
+	if strings.Contains(remote, "://") {
+		remote = remote[strings.Index(remote, "://")+3:]
+		if at := strings.Index(remote, "@"); at != -1 && at < strings.Index(remote+"/", "/") {
+			remote = remote[at+1:]
+		}
+		return "https://" + remote
+	}
+
+	if at := strings.Index(remote, "@"); at != -1 {
+		remote = remote[at+1:]
+	}
+	return "https://" + strings.Replace(remote, ":", "/", 1)
+}
+
+// iconURL returns where an icon in the project can be downloaded from, if the repository is on GitHub.
+func iconURL(dir, git, icon string) string {
+	repo := strings.TrimSuffix(git, ".git")
+	if !strings.HasPrefix(repo, "https://github.com/") {
+		return ""
+	}
+	top, err := gitOutput(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	branch, err := gitOutput(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return ""
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err == nil {
+		abs, err = filepath.EvalSymlinks(abs)
+	}
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(top, filepath.Join(abs, icon))
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return repo + "/blob/" + branch + "/" + filepath.ToSlash(rel) + "?raw=true"
+}
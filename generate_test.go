@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateEntry(t *testing.T) {
+	dir := t.TempDir()
+	appDir := filepath.Join(dir, "cmd", "thing")
+	assert.Nil(t, os.MkdirAll(appDir, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/thing\n\ngo 1.17\n"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(appDir, "FyneApp.toml"), []byte(`Website = "https://example.com"
+
+[Details]
+  Icon = "Icon.png"
+  Name = "Thing"
+  ID = "com.example.thing"
+  Version = "1.2.0"
+  Build = 3
+`), 0644))
+
+	a, warnings, err := generateEntry(appDir, App{Summary: "Does things", Category: "tools", Developer: "Me"})
+	assert.Nil(t, err)
+	assert.Equal(t, "com.example.thing", a.ID)
+	assert.Equal(t, "Thing", a.Name)
+	assert.Equal(t, "1.2.0", a.Version)
+	assert.Equal(t, "https://example.com", a.Website)
+	assert.Equal(t, "example.com/thing/cmd/thing", a.Source.Package)
+	assert.Equal(t, "Does things", a.Summary)
+	assert.Equal(t, "Me", a.Developer)
+	assert.NotEmpty(t, warnings) // not a git repository, so no remote or icon URL
+
+	_, _, err = generateEntry(dir, App{})
+	assert.NotNil(t, err)
+}
+
+func TestGitWebURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/fyne-io/apps.git", gitWebURL("git@github.com:fyne-io/apps.git"))
+	assert.Equal(t, "https://github.com/fyne-io/apps.git", gitWebURL("https://github.com/fyne-io/apps.git"))
+	assert.Equal(t, "https://github.com/fyne-io/apps.git", gitWebURL("ssh://git@github.com/fyne-io/apps.git"))
+	assert.Equal(t, "https://example.com/a@b/repo", gitWebURL("https://example.com/a@b/repo"))
+}
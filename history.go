@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// historyEntry records a single install, upgrade or rollback attempt.
+type historyEntry struct {
+	Date    time.Time `json:"date"`
+	Action  string    `json:"action"`
+	AppID   string    `json:"app_id"`
+	Version string    `json:"version"`
+	Package string    `json:"package"`
+	Commit  string    `json:"commit,omitempty"`
+
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+var historyHeaders = []string{"Date", "Action", "App ID", "Version", "Package", "Commit", "Duration", "Result"}
+
+func historyPath() string {
+	return filepath.Join(fyne.CurrentApp().Storage().RootURI().Path(), "history.json")
+}
+
+func loadHistory() []historyEntry {
+	data, err := os.ReadFile(historyPath())
+	if err != nil {
+		return nil
+	}
+
+	var list []historyEntry
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		fyne.LogError("Failed to read install history", err)
+	}
+	return list
+}
+
+func recordHistory(entry historyEntry) {
+	list := append(loadHistory(), entry)
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err == nil {
+		err = os.WriteFile(historyPath(), data, 0600)
+	}
+	if err != nil {
+		fyne.LogError("Failed to save install history", err)
+	}
+}
+
+func recordInstall(a App, action, source, commit string, start time.Time, err error) {
+	entry := historyEntry{Date: start, Action: action, AppID: a.ID, Version: a.Version,
+		Package: source, Commit: commit, Duration: time.Since(start)}
+	if entry.Version == "" {
+		entry.Version = versionLatest
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	recordHistory(entry)
+}
+
+func (h historyEntry) fields() []string {
+	result := "Success"
+	if h.Error != "" {
+		result = h.Error
+	}
+
+	return []string{h.Date.Format(time.RFC3339), h.Action, h.AppID, h.Version, h.Package, h.Commit,
+		strconv.FormatFloat(h.Duration.Seconds(), 'f', 1, 64) + "s", result}
+}
+
+func writeHistoryJSON(w io.Writer, list []historyEntry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(list)
+}
+
+func writeHistoryCSV(w io.Writer, list []historyEntry) error {
+	out := csv.NewWriter(w)
+	err := out.Write(historyHeaders)
+	if err != nil {
+		return err
+	}
+	for _, h := range list {
+		err = out.Write(h.fields())
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func showHistory() {
+	list := loadHistory()
+	table := widget.NewTableWithHeaders(
+		func() (int, int) {
+			return len(list), len(historyHeaders)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("io.fyne.example.app")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			// newest first
+			obj.(*widget.Label).SetText(list[len(list)-1-id.Row].fields()[id.Col])
+		})
+	table.ShowHeaderColumn = false
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabel("Header")
+	}
+	table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		if id.Row == -1 {
+			obj.(*widget.Label).SetText(historyHeaders[id.Col])
+		}
+	}
+
+	win := fyne.CurrentApp().NewWindow("Install History")
+	export := func(ext string, write func(io.Writer, []historyEntry) error) func() {
+		return func() {
+			save := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+				if err != nil || f == nil {
+					return
+				}
+				defer f.Close()
+
+				err = write(f, list)
+				if err != nil {
+					dialog.ShowError(err, win)
+				}
+			}, win)
+			save.SetFileName("install-history" + ext)
+			save.SetFilter(storage.NewExtensionFileFilter([]string{ext}))
+			save.Show()
+		}
+	}
+	buttons := container.NewHBox(
+		widget.NewButton("Export JSON", export(".json", writeHistoryJSON)),
+		widget.NewButton("Export CSV", export(".csv", writeHistoryCSV)))
+
+	win.SetContent(container.NewBorder(nil, container.NewCenter(buttons), nil, nil, table))
+	win.Resize(fyne.NewSize(760, 400))
+	win.Show()
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testHistory = []historyEntry{
+	{Date: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Action: "install", AppID: "xyz.andy.beebui",
+		Version: "latest", Package: "github.com/andydotxyz/beebui/cmd/beebui", Commit: "abc123",
+		Duration: 90 * time.Second},
+	{Date: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC), Action: "upgrade", AppID: "xyz.andy.beebui",
+		Version: "1.1", Package: "github.com/andydotxyz/beebui/cmd/beebui", Error: "build failed"},
+}
+
+func TestWriteHistoryCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := writeHistoryCSV(buf, testHistory)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Date,Action,App ID,Version,Package,Commit,Duration,Result\n"+
+		"2024-05-01T10:00:00Z,install,xyz.andy.beebui,latest,github.com/andydotxyz/beebui/cmd/beebui,abc123,90.0s,Success\n"+
+		"2024-05-02T10:00:00Z,upgrade,xyz.andy.beebui,1.1,github.com/andydotxyz/beebui/cmd/beebui,,0.0s,build failed\n",
+		buf.String())
+}
+
+func TestWriteHistoryJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := writeHistoryJSON(buf, testHistory)
+	assert.Nil(t, err)
+
+	var read []historyEntry
+	err = json.Unmarshal(buf.Bytes(), &read)
+	assert.Nil(t, err)
+	assert.Equal(t, testHistory, read)
+}
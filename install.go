@@ -0,0 +1,310 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/cmd/fyne/commands"
+)
+
+// installSourceCommand is the hidden command that builds and installs an app from a source folder.
+const installSourceCommand = "install-source"
+
+// installApp installs an app, preferring a verified prebuilt release and otherwise building
+// from source. A copy of the result is kept for rolling back.
+func installApp(a App) (err error) {
+	action := "install"
+	if installedVersion(a) != "" {
+		action = "upgrade"
+	}
+	start := time.Now()
+	source, commit := a.Source.Package, ""
+	defer func() {
+		recordInstall(a, action, source, commit, start, err)
+	}()
+
+	dir := appInstallDir(a)
+	err = checkInstallDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var verifyErr *securityError
+	if r := a.prebuilt(); r != nil {
+		source = r.URL
+		err = installPrebuilt(a, *r, dir)
+		if err != nil && !errors.As(err, &verifyErr) {
+			fyne.LogError("Failed to install prebuilt release, building from source", err)
+			source = a.Source.Package
+		}
+	}
+	if source == a.Source.Package {
+		var src *fetchedSource
+		src, err = fetchSource(a)
+		if err != nil {
+			return err
+		}
+		defer src.close()
+
+		commit = src.commit
+		err = verifySource(a, src)
+		if err != nil {
+			return err
+		}
+		err = buildApp(a, src.dir, dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	markInstalled(a)
+	rememberInstallDir(a, dir)
+	if storeErr := storeBuild(a); storeErr != nil {
+		fyne.LogError("Failed to keep build for rollback", storeErr)
+	}
+	return nil
+}
+
+// buildApp compiles an app from the source folder and installs it to the given location.
+// Unless turned off in the settings the build gets a clean environment in a sandbox workspace.
+func buildApp(a App, src, dir string) error {
+	env := os.Environ()
+	prefs := fyne.CurrentApp().Preferences()
+	if prefs.BoolWithFallback(keySandbox, true) {
+		s, err := newSandbox(prefs.Bool(keySandboxCache))
+		if err != nil {
+			return err
+		}
+		defer s.close()
+		env = s.env
+	}
+
+	return buildAppIn(a, src, dir, env)
+}
+
+// buildAppIn runs the install-source command of this program in the source folder, so that exactly
+// the code that was verified is built, with the environment given.
+func buildAppIn(a App, src, dir string, env []string) error {
+	tmpIcon := downloadIcon(a.Icon)
+	defer func() {
+		_ = os.Remove(tmpIcon)
+	}()
+
+	target := "" // the fyne installer's standard location
+	if dir != systemInstallDir() {
+		target = dir
+		switch runtime.GOOS {
+		case "darwin":
+		case "windows":
+			target = filepath.Join(dir, filepath.Base(a.Source.Package))
+		default:
+			// the installer creates a usr/local tree, so stage it before moving into our prefix
+			staged, err := os.MkdirTemp("", "fyne-apps-stage-*")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(staged)
+			target = staged
+		}
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := []string{installSourceCommand, "-appID", a.ID, "-release"}
+	if target != "" {
+		args = append(args, "-installDir", target)
+	}
+	if tmpIcon != "" {
+		args = append(args, "-icon", tmpIcon)
+	}
+	cmd := exec.Command(self, args...)
+	cmd.Dir, cmd.Env = src, env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	if target == "" || runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return nil
+	}
+	return movePrefix(target, dir)
+}
+
+// runInstallSource builds and installs the Fyne app in the current folder. The installer runs it as
+// a child process, as the fyne install command can only work on the current folder.
+// It returns the exit code for the command.
+func runInstallSource(args []string) int {
+	//lint:ignore SA1019 the installer is only available as a command
+	inst := commands.NewInstaller()
+	inst.AddFlags()
+	if flag.CommandLine.Parse(args) != nil || flag.NArg() != 0 {
+		return 2
+	}
+
+	inst.Run(nil) // exits if the install fails
+	return 0
+}
+
+// movePrefix copies a staged unix install into an install prefix. Icons are put in share/icons and the
+// desktop entries are updated to use the installed executable and icon by path, as the prefix may not
+// be on the PATH or searched for icons.
+func movePrefix(staged, prefix string) error {
+	root := filepath.Join(staged, "usr", "local")
+	if _, err := os.Stat(root); err != nil {
+		root = filepath.Join(staged, "usr")
+	}
+	prefix, err := filepath.Abs(prefix)
+	if err != nil {
+		return err
+	}
+
+	err = copyPath(filepath.Join(root, "bin"), filepath.Join(prefix, "bin"))
+	if err != nil {
+		return err
+	}
+	shared, err := os.ReadDir(filepath.Join(root, "share"))
+	if err != nil {
+		return err
+	}
+	icons := make(map[string]string)
+	for _, item := range shared {
+		src := filepath.Join(root, "share", item.Name())
+		switch item.Name() {
+		case "applications":
+		case "pixmaps":
+			files, err := os.ReadDir(src)
+			if err != nil {
+				return err
+			}
+			for _, f := range files {
+				dst := filepath.Join(prefix, "share", "icons", f.Name())
+				err = copyPath(filepath.Join(src, f.Name()), dst)
+				if err != nil {
+					return err
+				}
+				icons[strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))] = dst
+			}
+		default:
+			err = copyPath(src, filepath.Join(prefix, "share", item.Name()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "share", "applications"))
+	if err != nil {
+		return err
+	}
+	appsDir := filepath.Join(prefix, "share", "applications")
+	err = os.MkdirAll(appsDir, 0755)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(root, "share", "applications", e.Name()))
+		if err != nil {
+			return err
+		}
+		data = rewriteDesktopEntry(data, filepath.Join(prefix, "bin"), icons)
+		err = os.WriteFile(filepath.Join(appsDir, e.Name()), data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteDesktopEntry makes a relative Exec absolute within the bin folder given and replaces
+// icon names with the path to the matching icon file.
+func rewriteDesktopEntry(data []byte, binDir string, icons map[string]string) []byte {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "Exec="):
+			cmd := strings.TrimPrefix(line, "Exec=")
+			exe, args := cmd, ""
+			if pos := strings.Index(cmd, " "); pos != -1 {
+				exe, args = cmd[:pos], cmd[pos:]
+			}
+			if exe == "" || filepath.IsAbs(strings.Trim(exe, `"`)) {
+				continue
+			}
+
+			path := filepath.Join(binDir, exe)
+			if strings.ContainsAny(path, " \t") {
+				path = `"` + path + `"`
+			}
+			lines[i] = "Exec=" + path + args
+		case strings.HasPrefix(line, "Icon="):
+			if path, ok := icons[strings.TrimPrefix(line, "Icon=")]; ok {
+				lines[i] = "Icon=" + path
+			}
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// checkInstallDir makes sure we will be able to write the app before starting a long build.
+func checkInstallDir(dir string) error {
+	if runtime.GOOS == "windows" && dir == systemInstallDir() {
+		return nil // the installer will request permission
+	}
+
+	err := checkWritable(dir)
+	if err != nil {
+		return fmt.Errorf("cannot install to %s, choose another location in Settings: %w", dir, err)
+	}
+	return nil
+}
+
+func checkWritable(dir string) error {
+	if dir == "" {
+		return errors.New("no folder chosen")
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".fyne-apps-*")
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+func downloadIcon(url string) string {
+	req, err := http.Get(url)
+	if err != nil {
+		fyne.LogError("Failed to access icon url: "+url, err)
+		return ""
+	}
+	tmp := filepath.Join(os.TempDir(), "Fyne-Icon.png")
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		fyne.LogError("Failed tread icon data", err)
+		return ""
+	}
+
+	err = os.WriteFile(tmp, data, 0666)
+	if err != nil {
+		fyne.LogError("Failed to get write icon to: "+tmp, err)
+		return ""
+	}
+
+	return tmp
+}
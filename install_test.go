@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovePrefix(t *testing.T) {
+	staged := t.TempDir()
+	local := filepath.Join(staged, "usr", "local")
+	assert.Nil(t, os.MkdirAll(filepath.Join(local, "bin"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(local, "share", "applications"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(local, "bin", "beebui"), []byte("exe"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(local, "share", "pixmaps"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(local, "share", "pixmaps", "BeebUI.png"), []byte("png"), 0644))
+	entry := "[Desktop Entry]\nType=Application\nName=BeebUI\nExec=beebui %U\nIcon=BeebUI\n"
+	assert.Nil(t, os.WriteFile(filepath.Join(local, "share", "applications", "BeebUI.desktop"), []byte(entry), 0644))
+
+	prefix := t.TempDir()
+	err := movePrefix(staged, prefix)
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(prefix, "bin", "beebui"))
+	assert.FileExists(t, filepath.Join(prefix, "share", "icons", "BeebUI.png"))
+	assert.NoFileExists(t, filepath.Join(prefix, "share", "pixmaps", "BeebUI.png"))
+
+	data, err := os.ReadFile(filepath.Join(prefix, "share", "applications", "BeebUI.desktop"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "\nExec="+filepath.Join(prefix, "bin", "beebui")+" %U\n")
+	assert.Contains(t, string(data), "\nIcon="+filepath.Join(prefix, "share", "icons", "BeebUI.png")+"\n")
+}
+
+func TestRewriteDesktopEntry(t *testing.T) {
+	entry := []byte("[Desktop Entry]\nExec=/opt/app/bin/app\nIcon=Other\n")
+	assert.Equal(t, entry, rewriteDesktopEntry(entry, "/usr/local/bin", map[string]string{"App": "/app.png"}))
+
+	entry = []byte("Exec=app\n")
+	assert.Equal(t, "Exec=\"/my apps/bin/app\"\n", string(rewriteDesktopEntry(entry, "/my apps/bin", nil)))
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "apps")
+	assert.Nil(t, checkWritable(dir))
+	assert.DirExists(t, dir)
+
+	assert.NotNil(t, checkWritable(""))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// linkScheme is the URL scheme for links to catalog entries, such as fyne-apps://app/xyz.andy.beebui
+const linkScheme = "fyne-apps"
+
+// linkAppID returns the app ID from a fyne-apps:// link, or an empty string if it is not one.
+func linkAppID(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != linkScheme || u.Host != "app" {
+		return ""
+	}
+
+	return strings.Trim(u.Path, "/")
+}
+
+// linkSocket returns the path of the socket that a running instance listens on for links.
+func linkSocket() string {
+	return filepath.Join(fyne.CurrentApp().Storage().RootURI().Path(), "links.sock")
+}
+
+// forwardLink passes an app ID to an instance that is already running, returning false if there is none.
+// An empty ID just brings the running instance to the front.
+func forwardLink(path, id string) bool {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	_, err = fmt.Fprintln(conn, id)
+	if err != nil {
+		fyne.LogError("Failed to forward link", err)
+		return false
+	}
+	return true
+}
+
+// listenForLinks accepts links forwarded by later instances and sends each app ID to the channel.
+func listenForLinks(path string, links chan<- string) (net.Listener, error) {
+	_ = os.Remove(path) // left behind if an instance did not exit cleanly
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			conn.Close()
+			if err == nil {
+				links <- strings.TrimSpace(line)
+			}
+		}
+	}()
+	return l, nil
+}
+
+// openLink brings the window to the front and, if an ID is given, selects that app.
+func (w *welcome) openLink(id string, win fyne.Window) {
+	win.Show()
+	win.RequestFocus()
+	if id == "" {
+		return
+	}
+
+	if _, ok := w.apps[id]; !ok {
+		dialog.ShowError(fmt.Errorf("the app %s is not in the catalog", id), win)
+		return
+	}
+	w.selectApp(id)
+}
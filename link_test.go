@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkAppID(t *testing.T) {
+	assert.Equal(t, "xyz.andy.beebui", linkAppID("fyne-apps://app/xyz.andy.beebui"))
+	assert.Equal(t, "xyz.andy.beebui", linkAppID("fyne-apps://app/xyz.andy.beebui/"))
+	assert.Equal(t, "", linkAppID("fyne-apps://developer/andy"))
+	assert.Equal(t, "", linkAppID("https://app/xyz.andy.beebui"))
+	assert.Equal(t, "", linkAppID(""))
+}
+
+func TestForwardLink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.sock")
+	assert.False(t, forwardLink(path, "xyz.andy.beebui"))
+
+	links := make(chan string, 1)
+	l, err := listenForLinks(path, links)
+	assert.Nil(t, err)
+	defer l.Close()
+
+	assert.True(t, forwardLink(path, "xyz.andy.beebui"))
+	select {
+	case id := <-links:
+		assert.Equal(t, "xyz.andy.beebui", id)
+	case <-time.After(time.Second):
+		t.Error("Link was not received")
+	}
+}
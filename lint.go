@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+
+	maxIconSize       = 1 << 20
+	maxScreenshotSize = 4 << 20
+	maxImageDimension = 4096
+)
+
+// knownPlatforms are the operating systems that an app can list in its requires field.
+var knownPlatforms = []string{"android", "darwin", "freebsd", "ios", "js", "linux", "netbsd", "openbsd", "windows"}
+
+var goImportMeta = regexp.MustCompile(`<meta\s+name="go-import"\s+content="([^"]+)"`)
+
+// lintProblem is a problem found by the lint command, in the form printed as JSON.
+type lintProblem struct {
+	ID       string `json:"id"`
+	Field    string `json:"field,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (l lintProblem) String() string {
+	name := l.ID
+	if l.Field != "" {
+		name += " " + l.Field
+	}
+	return fmt.Sprintf("%s: %s: %s", name, l.Severity, l.Message)
+}
+
+// linter checks catalog entries, the client is used to check that links work unless it is offline.
+// Relative links are resolved against the source address, if it is known.
+type linter struct {
+	client  *http.Client
+	offline bool
+	source  *url.URL
+}
+
+// runLint checks a catalog file, or a single entry, for problems that would stop it working in the app.
+// It returns the exit code for the command.
+func runLint(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	offline := flags.Bool("offline", false, "skip the checks that download icons, screenshots and package info")
+	asJSON := flags.Bool("json", false, "print the problems found as JSON")
+	source := flags.String("source", "", "the address the catalog is published at, to check relative links")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: apps lint [-offline] [-json] [-source URL] list.json")
+		flags.PrintDefaults()
+	}
+	if flags.Parse(args) != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	l := &linter{client: &http.Client{Timeout: 20 * time.Second}, offline: *offline}
+	if *source != "" {
+		l.source, err = url.Parse(*source)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	problems, err := l.lintCatalog(data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not read catalog:", err)
+		return 2
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(problems)
+	} else {
+		err = writeLintText(out, problems)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	for _, p := range problems {
+		if p.Severity == severityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+func writeLintText(out io.Writer, problems []lintProblem) error {
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(out, p); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(out, "%d problems found\n", len(problems))
+	return err
+}
+
+// lintCatalog returns the problems with each entry in a catalog, or in a single entry.
+func (l *linter) lintCatalog(data []byte) ([]lintProblem, error) {
+	if isSingleEntry(data) {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	entries, err := decodeCatalog(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	apps, warnings := validateEntries(entries, l.source)
+	problems := []lintProblem{}
+	for _, w := range warnings {
+		severity := severityWarning
+		if w.Skipped {
+			severity = severityError
+		}
+		id := w.ID
+		if id == "" {
+			id = fmt.Sprintf("entry %d", w.Index)
+		}
+		problems = append(problems, lintProblem{ID: id, Field: w.Field, Severity: severity, Message: w.Message})
+	}
+	for _, a := range apps {
+		problems = append(problems, l.lintApp(a)...)
+	}
+	return problems, nil
+}
+
+func isSingleEntry(data []byte) bool {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return false
+	}
+
+	_, hasApps := fields["apps"]
+	return !hasApps
+}
+
+// lintApp checks the parts of an entry that parsing does not, including that its links work.
+func (l *linter) lintApp(a App) []lintProblem {
+	var ret []lintProblem
+	report := func(field, severity, msg string) {
+		ret = append(ret, lintProblem{ID: a.ID, Field: field, Severity: severity, Message: msg})
+	}
+
+	if a.Icon == "" {
+		report("icon", severityWarning, "missing icon")
+	} else if !l.offline {
+		for _, msg := range l.checkImage(a.Icon, maxIconSize) {
+			report("icon", severityWarning, msg)
+		}
+	}
+	if !l.offline {
+		for i, s := range a.Screenshots {
+			for _, msg := range l.checkImage(s.Image, maxScreenshotSize) {
+				report(fmt.Sprintf("screenshots.%d.image", i), severityWarning, msg)
+			}
+		}
+	}
+
+	if a.Requires != "" {
+		for _, r := range strings.Split(a.Requires, ",") {
+			if r != strings.TrimSpace(r) {
+				report("requires", severityError, fmt.Sprintf("platform %q must not contain spaces", r))
+			} else if !isKnownPlatform(r) {
+				report("requires", severityError, "unknown platform "+r)
+			}
+		}
+	}
+
+	if severity, msg := l.checkPackage(a.Source); msg != "" {
+		report("source.package", severity, msg)
+	}
+	return ret
+}
+
+// checkImage downloads an image and returns messages describing any problem with it.
+func (l *linter) checkImage(link string, maxSize int) []string {
+	if u, err := url.Parse(link); err == nil && !u.IsAbs() {
+		return []string{"relative link is not checked, pass -source to resolve it"}
+	}
+
+	res, err := l.client.Get(link)
+	if err != nil {
+		return []string{"unreachable: " + err.Error()}
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return []string{fmt.Sprintf("unreachable: status code %d", res.StatusCode)}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, int64(maxSize)+1))
+	if err != nil {
+		return []string{"unreachable: " + err.Error()}
+	}
+	if len(data) > maxSize {
+		return []string{fmt.Sprintf("image is larger than %d KB", maxSize/1024)}
+	}
+
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return []string{"not a supported image: " + err.Error()}
+	}
+	if conf.Width > maxImageDimension || conf.Height > maxImageDimension {
+		return []string{fmt.Sprintf("image is %dx%d, larger than %dx%d",
+			conf.Width, conf.Height, maxImageDimension, maxImageDimension)}
+	}
+	return nil
+}
+
+// checkPackage makes sure that the package is in the git repository, following vanity import paths if online.
+// It returns the severity and description of any problem found.
+func (l *linter) checkPackage(src AppSource) (string, string) {
+	repo := repoPath(src.Git)
+	if repo == "" || inRepo(src.Package, repo) {
+		return "", ""
+	}
+	if l.offline {
+		return severityWarning, "package is not in " + src.Git + " unless it is a vanity import path, " +
+			"which is not checked offline"
+	}
+
+	prefix, root := l.goImport(src.Package)
+	if prefix != "" && repoPath(root) == repo {
+		return "", ""
+	}
+	return severityError, "package is not in " + src.Git
+}
+
+// goImport looks up the go-import meta tag for a package, returning the import prefix and repository.
+func (l *linter) goImport(pkg string) (string, string) {
+	res, err := l.client.Get("https://" + pkg + "?go-get=1")
+	if err != nil {
+		return "", ""
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return "", ""
+	}
+	for _, match := range goImportMeta.FindAllSubmatch(data, -1) {
+		parts := strings.Fields(string(match[1]))
+		if len(parts) == 3 && parts[1] == "git" && inRepo(pkg, parts[0]) {
+			return parts[0], parts[2]
+		}
+	}
+	return "", ""
+}
+
+// repoPath returns the import path style location of a git URL, such as github.com/fyne-io/apps.
+func repoPath(git string) string {
+	u, err := url.Parse(git)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	return strings.ToLower(u.Host + strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), ".git"))
+}
+
+func inRepo(pkg, repo string) bool {
+	pkg = strings.ToLower(pkg)
+	return pkg == repo || strings.HasPrefix(pkg, repo+"/")
+}
+
+func isKnownPlatform(os string) bool {
+	for _, p := range knownPlatforms {
+		if p == os {
+			return true
+		}
+	}
+	return false
+}
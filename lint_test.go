@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintCatalog(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "invalid.json"))
+	assert.Nil(t, err)
+
+	l := &linter{offline: true}
+	problems, err := l.lintCatalog(data)
+	assert.Nil(t, err)
+	assert.Equal(t, lintProblem{ID: "entry 1", Field: "id", Severity: severityError,
+		Message: "missing required field"}, problems[0])
+	assert.Contains(t, problems, lintProblem{ID: "com.example.warnings", Field: "category",
+		Severity: severityWarning, Message: "unknown category Widgets"})
+
+	_, err = l.lintCatalog([]byte("[{]"))
+	assert.NotNil(t, err)
+}
+
+func TestLintApp(t *testing.T) {
+	l := &linter{offline: true}
+	entry := `{"id": "a", "name": "A", "requires": "linux, darwin,plan9", "category": "tools",
+		"source": {"git": "https://github.com/fyne-io/examples.git", "package": "github.com/fyne-io/example/cmd/a"},
+		"date": "2019-01-01T00:00:00Z"}`
+	problems, err := l.lintCatalog([]byte(entry))
+	assert.Nil(t, err)
+
+	var fields []string
+	for _, p := range problems {
+		fields = append(fields, p.Field+" "+p.Severity)
+	}
+	assert.Equal(t, []string{"icon warning", "requires error", "requires error", "source.package warning"}, fields)
+}
+
+func TestLinter_CheckImage(t *testing.T) {
+	var icon bytes.Buffer
+	assert.Nil(t, png.Encode(&icon, image.NewRGBA(image.Rect(0, 0, 16, 16))))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/icon.png":
+			_, _ = w.Write(icon.Bytes())
+		case "/text":
+			_, _ = w.Write([]byte("not an image"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	l := &linter{client: server.Client()}
+	assert.Empty(t, l.checkImage(server.URL+"/icon.png", maxIconSize))
+	assert.True(t, strings.HasPrefix(l.checkImage(server.URL+"/icon.png", 10)[0], "image is larger than"))
+	assert.True(t, strings.HasPrefix(l.checkImage(server.URL+"/text", maxIconSize)[0], "not a supported image"))
+	assert.Equal(t, []string{"unreachable: status code 404"}, l.checkImage(server.URL+"/missing.png", maxIconSize))
+}
+
+func TestRepoPath(t *testing.T) {
+	assert.Equal(t, "github.com/fyne-io/examples", repoPath("https://github.com/fyne-io/examples.git"))
+	assert.Equal(t, "github.com/fyne-io/examples", repoPath("https://github.com/Fyne-io/examples/"))
+	assert.Equal(t, "", repoPath("not a url"))
+
+	assert.True(t, inRepo("github.com/fyne-io/examples/cmd/bugs", "github.com/fyne-io/examples"))
+	assert.False(t, inRepo("github.com/fyne-io/examples2", "github.com/fyne-io/examples"))
+}
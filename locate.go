@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// versionUnknown is stored for apps found on disk that do not report their version.
+const versionUnknown = "unknown"
+
+// installedPath returns the location of the installed executable (or app bundle on macOS),
+// or an empty string if the app could not be found.
+// On Linux and BSD only the app's desktop entry is trusted, as executable names are often shared.
+func installedPath(a App) string {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		return desktopEntryExec(a)
+	}
+
+	for _, p := range installCandidates(a) {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// installCandidates lists the places that an app may be installed to on this system,
+// starting with the location remembered from installing it.
+func installCandidates(a App) []string {
+	dirs := []string{systemInstallDir()}
+	if dir := fyne.CurrentApp().Preferences().String(keyLocationPrefix + a.ID); dir != "" && dir != dirs[0] {
+		dirs = append([]string{dir}, dirs...)
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		paths = append(paths, candidatesIn(a, dir)...)
+	}
+	return paths
+}
+
+func candidatesIn(a App, dir string) []string {
+	exe := filepath.Base(a.Source.Package)
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{filepath.Join(dir, a.Name+".app"), filepath.Join(dir, exe+".app")}
+	case "windows":
+		return []string{filepath.Join(dir, a.Name, exe+".exe"), filepath.Join(dir, exe, exe+".exe")}
+	default:
+		return []string{filepath.Join(dir, "bin", exe)}
+	}
+}
+
+// detectInstall looks for an app on disk, returning its location and, if the package metadata
+// includes it, the installed version.
+func detectInstall(a App) (path, version string) {
+	path = installedPath(a)
+	if path == "" || runtime.GOOS != "darwin" {
+		return path, ""
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, "Contents", "Info.plist"))
+	if err != nil {
+		return path, ""
+	}
+	if id := plistValue(data, "CFBundleIdentifier"); id != "" && id != a.ID {
+		return "", "" // a different app with the same name
+	}
+	return path, plistValue(data, "CFBundleShortVersionString")
+}
+
+// reconcileInstall corrects the stored install state of an app against what is present on disk,
+// returning the installed version. A recorded install is cleared if the app is not found in any
+// searched location, which includes the location remembered from installing it.
+func reconcileInstall(a App) string {
+	stored := installedVersion(a)
+	path, ver := detectInstall(a)
+	switch {
+	case path == "":
+		if stored != "" {
+			fyne.CurrentApp().Preferences().RemoveValue(keyInstallPrefix + a.ID)
+		}
+		return ""
+	case ver != "" && ver != stored && stored != versionLatest:
+		setInstalledVersion(a, ver)
+		return ver
+	case stored == "":
+		if ver == "" {
+			ver = versionUnknown
+		}
+		setInstalledVersion(a, ver)
+		return ver
+	}
+
+	return stored
+}
+
+func reconcileInstalled(apps AppList) {
+	for _, a := range apps {
+		reconcileInstall(a)
+	}
+}
+
+var plistKeyPattern = regexp.MustCompile(`<key>([^<]+)</key>\s*<string>([^<]*)</string>`)
+
+func plistValue(data []byte, key string) string {
+	for _, match := range plistKeyPattern.FindAllSubmatch(data, -1) {
+		if string(match[1]) == key {
+			return string(match[2])
+		}
+	}
+
+	return ""
+}
+
+// desktopEntryDirs lists the folders that desktop entries are installed to, starting with the
+// location remembered from installing the app.
+func desktopEntryDirs(a App) []string {
+	dirs := []string{
+		filepath.Join("/usr", "local", "share", "applications"),
+		filepath.Join("/usr", "share", "applications"),
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".local", "share", "applications"))
+	}
+	if dir := fyne.CurrentApp().Preferences().String(keyLocationPrefix + a.ID); dir != "" {
+		dirs = append([]string{filepath.Join(dir, "share", "applications")}, dirs...)
+	}
+	return dirs
+}
+
+// desktopEntryExec finds the executable named by the app's desktop entry, if it is installed.
+func desktopEntryExec(a App) string {
+	for _, dir := range desktopEntryDirs(a) {
+		if exe := entryExecIn(dir, a.Name); exe != "" {
+			return exe
+		}
+	}
+
+	return ""
+}
+
+// entryExecIn returns the executable of a desktop entry in a folder, if it exists. A relative name
+// is looked for in the bin folder of the same prefix and then on the PATH.
+func entryExecIn(dir, name string) string {
+	exe := desktopFileExec(filepath.Join(dir, name+".desktop"))
+	if exe == "" {
+		return ""
+	}
+
+	if !filepath.IsAbs(exe) {
+		local := filepath.Join(filepath.Dir(filepath.Dir(dir)), "bin", exe)
+		if _, err := os.Stat(local); err == nil {
+			return local
+		}
+
+		found, err := exec.LookPath(exe)
+		if err != nil {
+			return ""
+		}
+		exe = found
+	}
+	if _, err := os.Stat(exe); err != nil {
+		return ""
+	}
+	return exe
+}
+
+func desktopFileExec(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if !strings.HasPrefix(line, "Exec=") {
+			continue
+		}
+
+		cmd := strings.TrimPrefix(line, "Exec=")
+		if strings.HasPrefix(cmd, `"`) {
+			if end := strings.Index(cmd[1:], `"`); end != -1 {
+				return cmd[1 : end+1]
+			}
+		}
+		fields := strings.Fields(cmd)
+		if len(fields) == 0 {
+			return ""
+		}
+		return strings.Trim(fields[0], `"`)
+	}
+
+	return ""
+}
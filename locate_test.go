@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlistValue(t *testing.T) {
+	plist := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>xyz.andy.beebui</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.3</string>
+</dict>
+</plist>`)
+
+	assert.Equal(t, "xyz.andy.beebui", plistValue(plist, "CFBundleIdentifier"))
+	assert.Equal(t, "1.2.3", plistValue(plist, "CFBundleShortVersionString"))
+	assert.Equal(t, "", plistValue(plist, "CFBundleName"))
+}
+
+func TestDesktopFileExec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "BeebUI.desktop")
+	err := os.WriteFile(path, []byte("[Desktop Entry]\nType=Application\nName=BeebUI\nExec=/usr/local/bin/beebui %U\n"), 0644)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "/usr/local/bin/beebui", desktopFileExec(path))
+	assert.Equal(t, "", desktopFileExec(filepath.Join(t.TempDir(), "missing.desktop")))
+
+	err = os.WriteFile(path, []byte("[Desktop Entry]\nExec=\"/my apps/bin/beebui\" %U\n"), 0644)
+	assert.Nil(t, err)
+	assert.Equal(t, "/my apps/bin/beebui", desktopFileExec(path))
+}
+
+func TestEntryExecIn(t *testing.T) {
+	prefix := t.TempDir()
+	apps := filepath.Join(prefix, "share", "applications")
+	assert.Nil(t, os.MkdirAll(apps, 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(prefix, "bin"), 0755))
+	err := os.WriteFile(filepath.Join(apps, "BeebUI.desktop"), []byte("[Desktop Entry]\nExec=beebui\n"), 0644)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "", entryExecIn(apps, "BeebUI"))
+	assert.Equal(t, "", entryExecIn(apps, "Other"))
+
+	exe := filepath.Join(prefix, "bin", "beebui")
+	assert.Nil(t, os.WriteFile(exe, []byte("exe"), 0755))
+	assert.Equal(t, exe, entryExecIn(apps, "BeebUI"))
+}
+
+func TestReconcileInstall(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("installs are detected by desktop entry on Linux and BSD only")
+	}
+	test.NewTempApp(t)
+	t.Setenv("HOME", t.TempDir())
+	prefs := fyne.CurrentApp().Preferences()
+	a := App{ID: "xyz.test.reconcile", Name: "Reconcile Test App", Source: AppSource{Package: "github.com/test/reconcile"}}
+
+	prefix := t.TempDir()
+	prefs.SetString(keyLocationPrefix+a.ID, prefix)
+	setInstalledVersion(a, "1.0.0")
+	assert.Equal(t, "", reconcileInstall(a))
+	assert.Equal(t, "", installedVersion(a))
+
+	apps := filepath.Join(prefix, "share", "applications")
+	assert.Nil(t, os.MkdirAll(apps, 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(prefix, "bin"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(prefix, "bin", "reconcile"), []byte("exe"), 0755))
+	err := os.WriteFile(filepath.Join(apps, a.Name+".desktop"), []byte("[Desktop Entry]\nExec=reconcile\n"), 0644)
+	assert.Nil(t, err)
+	assert.Equal(t, versionUnknown, reconcileInstall(a))
+	assert.Equal(t, versionUnknown, installedVersion(a))
+
+	setInstalledVersion(a, "1.0.0")
+	assert.Equal(t, "1.0.0", reconcileInstall(a))
+}
@@ -3,34 +3,76 @@
 package main
 
 import (
-	"log"
+	"errors"
+	"flag"
+	"os"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "lint":
+			os.Exit(runLint(os.Args[2:], os.Stdout))
+		case "generate":
+			os.Exit(runGenerate(os.Args[2:], os.Stdout))
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case installSourceCommand:
+			os.Exit(runInstallSource(os.Args[2:]))
+		}
+	}
+
+	show := flag.String("show", "", "open the catalog entry for the app with this ID")
+	flag.StringVar(&catalogURLFlag, "catalog", "", "the address of the app catalog, such as "+defaultCatalogURL)
+	flag.Parse()
+	if id := linkAppID(flag.Arg(0)); id != "" {
+		*show = id
+	}
+
 	a := app.NewWithID("io.fyne.apps")
+	if forwardLink(linkSocket(), *show) {
+		return
+	}
+	links := make(chan string, 1)
+	if *show != "" {
+		links <- *show
+	}
+	l, err := listenForLinks(linkSocket(), links)
+	if err != nil {
+		fyne.LogError("Unable to listen for links", err)
+	} else {
+		defer l.Close()
+	}
+
 	a.SetIcon(resourceIconPng)
 	w := a.NewWindow("Fyne Applications")
 
-	data, err := loadAppListFromWeb()
-	if err != nil {
-		log.Println("Web failed, reading cache")
-		data, err = loadAppListFromCache()
-		if err != nil {
-			fyne.LogError("Load error", err)
+	cat, err := fetchAppList()
+	if cat.apps == nil {
+		fyne.LogError("Load error", err)
+		var tooNew *schemaError
+		if !errors.As(err, &tooNew) {
 			return
 		}
-	}
-	defer data.Close()
-	apps, err := parseAppList(data)
-	if err != nil {
-		fyne.LogError("Parse error", err)
+
+		msg := widget.NewLabel(err.Error())
+		msg.Wrapping = fyne.TextWrapWord
+		w.SetContent(container.NewCenter(msg))
+		w.Resize(fyne.NewSize(480, 200))
+		w.ShowAndRun()
 		return
 	}
-	w.SetContent(loadUI(apps, w))
+	w.SetContent(loadUI(cat, w, links))
 	w.Resize(fyne.NewSize(680, 520))
+	if err != nil {
+		dialog.ShowError(err, w)
+	}
 
 	w.ShowAndRun()
 }
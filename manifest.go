@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	changeInstall = "install"
+	changeUpgrade = "upgrade"
+	changeSkip    = "skip"
+)
+
+// manifest lists the apps installed on a computer, so the same set can be installed elsewhere.
+type manifest struct {
+	OS   string          `json:"os"`
+	Apps []manifestEntry `json:"apps"`
+}
+
+type manifestEntry struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// manifestChange describes what importing a manifest will do for one of its apps.
+type manifestChange struct {
+	Entry  manifestEntry
+	App    App
+	Action string
+	Reason string
+}
+
+func (c manifestChange) String() string {
+	switch c.Action {
+	case changeInstall:
+		return fmt.Sprintf("Install %s %s (%s)", c.App.Name, c.App.Version, trustDescription(c.App.trustLevel()))
+	case changeUpgrade:
+		return fmt.Sprintf("Upgrade %s from %s to %s (%s)", c.App.Name, installedVersion(c.App), c.App.Version,
+			trustDescription(c.App.trustLevel()))
+	}
+
+	name := c.Entry.ID
+	if c.App.Name != "" {
+		name = c.App.Name
+	}
+	return fmt.Sprintf("Skip %s: %s", name, c.Reason)
+}
+
+// installedManifest returns a manifest of the apps in the list that are installed.
+func installedManifest(list AppList) manifest {
+	m := manifest{OS: runtime.GOOS}
+	for id, a := range list {
+		if ver := installedVersion(a); ver != "" {
+			m.Apps = append(m.Apps, manifestEntry{ID: id, Version: ver})
+		}
+	}
+
+	sort.Slice(m.Apps, func(i, j int) bool {
+		return m.Apps[i].ID < m.Apps[j].ID
+	})
+	return m
+}
+
+func readManifest(r io.Reader) (manifest, error) {
+	var m manifest
+	err := json.NewDecoder(r).Decode(&m)
+	if err == nil && len(m.Apps) == 0 {
+		err = errors.New("manifest does not list any apps")
+	}
+	return m, err
+}
+
+func writeManifest(w io.Writer, m manifest) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// planManifest works out which apps in a manifest need installing or upgrading on the given OS.
+// The list should include apps for all platforms, so that those not available here are reported.
+// Apps are always installed at the catalog version, which may differ from the version in the manifest.
+func planManifest(m manifest, list AppList, goos string) []manifestChange {
+	changes := make([]manifestChange, len(m.Apps))
+	for i, e := range m.Apps {
+		c := manifestChange{Entry: e, Action: changeSkip}
+		a, ok := list[e.ID]
+		c.App = a
+		switch {
+		case !ok:
+			c.Reason = "not in the catalog"
+		case !a.isCompatibleWithOS(goos):
+			c.Reason = "not available for " + goos
+		case installedVersion(a) == "":
+			c.Action = changeInstall
+		case hasUpdate(a):
+			c.Action = changeUpgrade
+		default:
+			c.Reason = "already installed"
+		}
+
+		changes[i] = c
+	}
+	return changes
+}
+
+func (w *welcome) exportManifest(win fyne.Window) {
+	save := dialog.NewFileSave(func(f fyne.URIWriteCloser, err error) {
+		if err != nil || f == nil {
+			return
+		}
+		defer f.Close()
+
+		err = writeManifest(f, installedManifest(w.apps))
+		if err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+	save.SetFileName("installed-apps.json")
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+func (w *welcome) importManifest(win fyne.Window) {
+	open := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+		if err != nil || f == nil {
+			return
+		}
+		defer f.Close()
+
+		m, err := readManifest(f)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		w.previewManifest(planManifest(m, w.allApps, runtime.GOOS), win)
+	}, win)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+// previewManifest lists the changes an import will make and installs the apps once confirmed.
+func (w *welcome) previewManifest(changes []manifestChange, win fyne.Window) {
+	var pending []App
+	for _, c := range changes {
+		if c.Action != changeSkip {
+			pending = append(pending, c.App)
+		}
+	}
+
+	list := widget.NewList(
+		func() int {
+			return len(changes)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Upgrade A longish app name from 1.0.0 to 1.1.0")
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(changes[id].String())
+		})
+	if len(pending) == 0 {
+		d := dialog.NewCustom("Import Installed Apps", "Close", list, win)
+		d.Resize(fyne.NewSize(520, 320))
+		d.Show()
+		return
+	}
+
+	confirm := dialog.NewCustomConfirm(fmt.Sprintf("Install %d apps?", len(pending)), "Install", "Cancel", list,
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			confirmTrust(pending, win, func() {
+				w.installApps(pending, win)
+			})
+		}, win)
+	confirm.Resize(fyne.NewSize(520, 320))
+	confirm.Show()
+}
+
+// installApps installs each of the apps in turn, reporting any that failed once all are done.
+func (w *welcome) installApps(apps []App, win fyne.Window) {
+	bar := widget.NewProgressBar()
+	bar.Max = float64(len(apps))
+	status := widget.NewLabel("Please wait while the apps are installed")
+	prog := dialog.NewCustomWithoutButtons("Installing...", container.NewVBox(status, bar), win)
+	prog.Show()
+
+	go func() {
+		var failed []string
+		for i, a := range apps {
+			name, done := a.Name, float64(i)
+			fyne.Do(func() {
+				status.SetText("Installing " + name)
+				bar.SetValue(done)
+			})
+
+			err := installApp(a)
+			if err != nil {
+				fyne.LogError("Failed to install "+a.ID, err)
+				failed = append(failed, a.Name+": "+err.Error())
+			}
+		}
+
+		fyne.Do(func() {
+			prog.Hide()
+			w.updateVirtualNodes()
+			if w.detail.Visible() {
+				w.loadAppDetail(w.shownApp)
+			}
+			if len(failed) > 0 {
+				dialog.ShowError(fmt.Errorf("some apps could not be installed:\n%s", strings.Join(failed, "\n")), win)
+				return
+			}
+			dialog.ShowInformation("Installed", fmt.Sprintf("%d apps were installed successfully", len(apps)), win)
+		})
+	}()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstalledManifest(t *testing.T) {
+	test.NewTempApp(t)
+	list := AppList{
+		"b": App{ID: "b", Name: "Calculator", Version: "1.0"},
+		"a": App{ID: "a", Name: "Bugs", Version: "1.1"},
+		"c": App{ID: "c", Name: "BeebUI"},
+	}
+	setInstalledVersion(list["a"], "1.0")
+	markInstalled(list["b"])
+
+	m := installedManifest(list)
+	assert.Equal(t, []manifestEntry{{ID: "a", Version: "1.0"}, {ID: "b", Version: "1.0"}}, m.Apps)
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, writeManifest(buf, m))
+	read, err := readManifest(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, m, read)
+
+	_, err = readManifest(bytes.NewBufferString(`{"apps": []}`))
+	assert.NotNil(t, err)
+}
+
+func TestPlanManifest(t *testing.T) {
+	test.NewTempApp(t)
+	list := AppList{
+		"a": App{ID: "a", Name: "Bugs", Version: "1.1"},
+		"b": App{ID: "b", Name: "Calculator", Version: "1.0"},
+		"c": App{ID: "c", Name: "BeebUI", Version: "1.0"},
+		"d": App{ID: "d", Name: "Mac Only", Requires: "darwin"},
+	}
+	setInstalledVersion(list["a"], "1.0")
+	markInstalled(list["b"])
+
+	m := manifest{Apps: []manifestEntry{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "missing"}}}
+	changes := planManifest(m, list, "linux")
+	actions := make([]string, len(changes))
+	for i, c := range changes {
+		actions[i] = c.Action
+	}
+	assert.Equal(t, []string{changeUpgrade, changeSkip, changeInstall, changeSkip, changeSkip}, actions)
+	assert.Equal(t, "already installed", changes[1].Reason)
+	assert.Equal(t, "not available for linux", changes[3].Reason)
+	assert.Equal(t, "not in the catalog", changes[4].Reason)
+	assert.Equal(t, "Skip missing: not in the catalog", changes[4].String())
+
+	changes = planManifest(m, list, "darwin")
+	assert.Equal(t, changeInstall, changes[3].Action)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+const (
+	keyCatalogMirrors = "catalog.mirrors."
+	envCatalogMirrors = "FYNE_APPS_MIRRORS"
+)
+
+// mirrors tracks how well each catalog address has worked in this session.
+var mirrors = &mirrorHealth{failures: make(map[string]int)}
+
+// mirrorHealth counts the failed requests to each catalog address, so that mirrors which
+// are not working are tried after the others for the rest of the session.
+type mirrorHealth struct {
+	lock     sync.Mutex
+	failures map[string]int
+}
+
+func (m *mirrorHealth) failed(base string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.failures[base]++
+}
+
+// order returns the addresses with those that have failed least first, otherwise keeping the order given.
+func (m *mirrorHealth) order(bases []string) []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ret := append([]string{}, bases...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return m.failures[ret[i]] < m.failures[ret[j]]
+	})
+	return ret
+}
+
+// mirrorsKey returns the preference that holds the mirrors of a catalog, as each catalog has its own.
+func mirrorsKey(base string) string {
+	return keyCatalogMirrors + base
+}
+
+// catalogBases returns the catalog address followed by its mirrors, from the environment or settings.
+func catalogBases() []string {
+	base := catalogBase()
+	list := os.Getenv(envCatalogMirrors)
+	if list == "" {
+		list = fyne.CurrentApp().Preferences().String(mirrorsKey(base))
+	}
+
+	bases := []string{base}
+	for _, m := range strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == '\n' || r == ' '
+	}) {
+		m = strings.TrimRight(m, "/")
+		found := false
+		for _, b := range bases {
+			found = found || b == m
+		}
+		if !found {
+			bases = append(bases, m)
+		}
+	}
+	return bases
+}
+
+// getFromMirrors requests a path from each catalog address in turn, returning the first good response
+// and the address that served it.
+func getFromMirrors(path string) (*http.Response, string, error) {
+	var errs []string
+	for _, base := range mirrors.order(catalogBases()) {
+		res, err := http.Get(base + path)
+		if err == nil && res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			err = fmt.Errorf("unexpected status code: %d", res.StatusCode)
+		}
+		if err == nil {
+			return res, base, nil
+		}
+
+		mirrors.failed(base)
+		errs = append(errs, base+": "+err.Error())
+	}
+
+	return nil, "", errors.New(strings.Join(errs, ", "))
+}
+
+// mirrorURLs returns the addresses to try for a link, if it is on the catalog or a mirror
+// then the same path on each of the others is included in order of health.
+func mirrorURLs(link string) []string {
+	bases := catalogBases()
+	for _, base := range bases {
+		if !strings.HasPrefix(link, base+"/") {
+			continue
+		}
+
+		path := link[len(base):]
+		var ret []string
+		for _, b := range mirrors.order(bases) {
+			ret = append(ret, b+path)
+		}
+		return ret
+	}
+
+	return []string{link}
+}
+
+// mirrorBase returns the catalog address or mirror that a link is on, if any.
+func mirrorBase(link string) string {
+	for _, base := range catalogBases() {
+		if strings.HasPrefix(link, base+"/") {
+			return base
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFromMirrors(t *testing.T) {
+	test.NewTempApp(t)
+	mirrors = &mirrorHealth{failures: make(map[string]int)}
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+	var requests int32
+	working := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer working.Close()
+
+	t.Setenv(envCatalogURL, broken.URL)
+	t.Setenv(envCatalogMirrors, working.URL+"/, "+broken.URL)
+	assert.Equal(t, []string{broken.URL, working.URL}, catalogBases())
+
+	res, base, err := getFromMirrors(apiPath("list.json"))
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, working.URL, base)
+	assert.Equal(t, []string{working.URL, broken.URL}, mirrors.order(catalogBases()))
+
+	working.Close()
+	_, _, err = getFromMirrors(apiPath("list.json"))
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestMirrorURLs(t *testing.T) {
+	test.NewTempApp(t)
+	mirrors = &mirrorHealth{failures: make(map[string]int)}
+	t.Setenv(envCatalogURL, "https://apps.example.com")
+	t.Setenv(envCatalogMirrors, "https://mirror.example.com")
+
+	mirrors.failed("https://apps.example.com")
+	assert.Equal(t, []string{"https://mirror.example.com/icon.png", "https://apps.example.com/icon.png"},
+		mirrorURLs("https://apps.example.com/icon.png"))
+	assert.Equal(t, []string{"https://other.example.com/icon.png"}, mirrorURLs("https://other.example.com/icon.png"))
+	assert.Equal(t, "https://mirror.example.com", mirrorBase("https://mirror.example.com/icon.png"))
+	assert.Equal(t, "", mirrorBase("https://other.example.com/icon.png"))
+}
+
+func TestCatalogBases_PerCatalog(t *testing.T) {
+	test.NewTempApp(t)
+	t.Setenv(envCatalogMirrors, "")
+	prefs := fyne.CurrentApp().Preferences()
+	prefs.SetString(mirrorsKey(defaultCatalogURL), "https://mirror.example.com")
+
+	t.Setenv(envCatalogURL, "")
+	assert.Equal(t, []string{defaultCatalogURL, "https://mirror.example.com"}, catalogBases())
+
+	t.Setenv(envCatalogURL, "https://private.example.com")
+	assert.Equal(t, []string{"https://private.example.com"}, catalogBases())
+}
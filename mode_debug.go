@@ -0,0 +1,7 @@
+//go:build debug
+// +build debug
+
+package main
+
+// debugMode is set when built with the debug tag, enabling extra checks of the catalog.
+const debugMode = true
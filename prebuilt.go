@@ -0,0 +1,236 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// prebuilt returns the release matching the current platform, if there is a verifiable one.
+func (a App) prebuilt() *AppRelease {
+	return a.prebuiltFor(runtime.GOOS, runtime.GOARCH)
+}
+
+func (a App) prebuiltFor(goos, goarch string) *AppRelease {
+	for i, r := range a.Releases {
+		if r.OS != goos || (r.Arch != "" && r.Arch != goarch) {
+			continue
+		}
+		if r.URL == "" || r.SHA256 == "" {
+			continue
+		}
+
+		return &a.Releases[i]
+	}
+
+	return nil
+}
+
+// installPrebuilt downloads and verifies a release, then installs it to the given location.
+func installPrebuilt(a App, r AppRelease, dir string) error {
+	tmp, err := os.CreateTemp("", "fyne-apps-download-*"+releaseExt(r.URL))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	res, err := http.Get(r.URL)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+	err = verifyDownload(io.TeeReader(res.Body, tmp), r)
+	if err != nil {
+		return err
+	}
+
+	staged, err := os.MkdirTemp("", "fyne-apps-stage-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staged)
+	err = extractRelease(tmp.Name(), staged, filepath.Base(a.Source.Package))
+	if err != nil {
+		return err
+	}
+
+	return placeRelease(a, staged, dir)
+}
+
+func verifyDownload(in io.Reader, r AppRelease) error {
+	hash := sha256.New()
+	size, err := io.Copy(hash, in)
+	if err != nil {
+		return err
+	}
+
+	if r.Size > 0 && size != r.Size {
+		return &securityError{msg: fmt.Sprintf("download of %s has size %d, expected %d", r.URL, size, r.Size)}
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(sum, r.SHA256) {
+		return &securityError{msg: fmt.Sprintf("download of %s has sha256 %s, expected %s", r.URL, sum,
+			strings.ToLower(r.SHA256))}
+	}
+	return nil
+}
+
+func releaseExt(url string) string {
+	url = strings.SplitN(url, "?", 2)[0]
+	if strings.HasSuffix(url, ".tar.gz") {
+		return ".tar.gz"
+	}
+	return filepath.Ext(url)
+}
+
+// extractRelease unpacks a downloaded archive, or copies a bare executable, into the staging folder.
+func extractRelease(file, staged, exe string) error {
+	switch releaseExt(file) {
+	case ".zip":
+		return extractZip(file, staged)
+	case ".tar.gz", ".tgz":
+		return extractTarGz(file, staged)
+	}
+
+	if runtime.GOOS == "windows" {
+		exe += ".exe"
+	}
+	return copyPath(file, filepath.Join(staged, exe))
+}
+
+// placeRelease moves the staged release content into the install location.
+func placeRelease(a App, staged, dir string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		apps, _ := filepath.Glob(filepath.Join(staged, "*.app"))
+		if len(apps) == 0 {
+			return fmt.Errorf("release for %s did not contain an app bundle", a.ID)
+		}
+		for _, app := range apps {
+			if err := replacePath(app, filepath.Join(dir, filepath.Base(app))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "windows":
+		return copyPath(staged, filepath.Join(dir, filepath.Base(a.Source.Package)))
+	}
+
+	if _, err := os.Stat(filepath.Join(staged, "usr")); err == nil {
+		return movePrefix(staged, dir)
+	}
+	if _, err := os.Stat(filepath.Join(staged, "bin")); err == nil {
+		return copyPath(filepath.Join(staged, "bin"), filepath.Join(dir, "bin"))
+	}
+	return copyPath(staged, filepath.Join(dir, "bin"))
+}
+
+// stagedPath returns the location to extract an archive entry to, refusing paths that escape.
+func stagedPath(staged, name string) (string, error) {
+	path := filepath.Join(staged, name)
+	if path != filepath.Clean(staged) && !strings.HasPrefix(path, filepath.Clean(staged)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid path in archive: %s", name)
+	}
+	return path, nil
+}
+
+func extractZip(file, staged string) error {
+	z, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	defer z.Close()
+
+	for _, f := range z.File {
+		path, err := stagedPath(staged, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			err = os.MkdirAll(path, 0755)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		in, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeStaged(path, in, f.Mode())
+		_ = in.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTarGz(file, staged string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	in := tar.NewReader(gz)
+	for {
+		head, err := in.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		path, err := stagedPath(staged, head.Name)
+		if err != nil {
+			return err
+		}
+		switch head.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+		case tar.TypeReg:
+			err = writeStaged(path, in, head.FileInfo().Mode())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func writeStaged(path string, in io.Reader, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
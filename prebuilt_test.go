@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppList_Releases(t *testing.T) {
+	list, err := parseAppList(strings.NewReader(`[{"id": "com.example.app", "name": "App",
+  "source": {"package": "example.com/app"}, "releases": [
+  {"os": "linux", "arch": "amd64", "url": "https://example.com/app.tar.gz", "size": 42, "sha256": "abc123"}
+]}]`))
+	assert.Nil(t, err)
+
+	r := list["com.example.app"].Releases
+	assert.Equal(t, 1, len(r))
+	assert.Equal(t, "linux", r[0].OS)
+	assert.Equal(t, "amd64", r[0].Arch)
+	assert.Equal(t, "https://example.com/app.tar.gz", r[0].URL)
+	assert.Equal(t, int64(42), r[0].Size)
+	assert.Equal(t, "abc123", r[0].SHA256)
+}
+
+func TestApp_PrebuiltFor(t *testing.T) {
+	a := App{Releases: []AppRelease{
+		{OS: "linux", Arch: "arm64", URL: "https://example.com/arm.tar.gz", SHA256: "abc"},
+		{OS: "linux", URL: "https://example.com/unverified.tar.gz"},
+		{OS: "darwin", URL: "https://example.com/mac.zip", SHA256: "def"},
+	}}
+
+	assert.Equal(t, "https://example.com/arm.tar.gz", a.prebuiltFor("linux", "arm64").URL)
+	assert.Nil(t, a.prebuiltFor("linux", "amd64"))
+	assert.Equal(t, "https://example.com/mac.zip", a.prebuiltFor("darwin", "amd64").URL)
+	assert.Nil(t, a.prebuiltFor("windows", "amd64"))
+}
+
+func TestVerifyDownload(t *testing.T) {
+	// sha256 of "hello"
+	sum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	assert.Nil(t, verifyDownload(strings.NewReader("hello"), AppRelease{SHA256: sum}))
+	assert.Nil(t, verifyDownload(strings.NewReader("hello"), AppRelease{SHA256: strings.ToUpper(sum), Size: 5}))
+
+	err := verifyDownload(strings.NewReader("hello"), AppRelease{SHA256: sum, Size: 6})
+	assert.IsType(t, &securityError{}, err)
+	err = verifyDownload(strings.NewReader("goodbye"), AppRelease{SHA256: sum})
+	assert.IsType(t, &securityError{}, err)
+}
+
+func TestStagedPath(t *testing.T) {
+	staged := t.TempDir()
+	path, err := stagedPath(staged, "usr/local/bin/app")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(staged, "usr", "local", "bin", "app"), path)
+
+	_, err = stagedPath(staged, "../../etc/passwd")
+	assert.NotNil(t, err)
+}
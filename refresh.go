@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// refreshShortcut reloads the catalog, as Ctrl+R or Cmd+R on macOS.
+var refreshShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyR, Modifier: fyne.KeyModifierShortcutDefault}
+
+// catalogDiff describes the apps that were added, removed or changed between two copies of the catalog.
+type catalogDiff struct {
+	added, removed, changed []string
+}
+
+// diffAppLists compares a newly loaded catalog to the current one, each list is sorted by app name.
+// An app has changed if a new version or release date is listed.
+func diffAppLists(old, fresh AppList) catalogDiff {
+	var diff catalogDiff
+	for id, a := range fresh {
+		prev, ok := old[id]
+		if !ok {
+			diff.added = append(diff.added, id)
+		} else if prev.Version != a.Version || !prev.Date.Equal(a.Date) {
+			diff.changed = append(diff.changed, id)
+		}
+	}
+	for id := range old {
+		if _, ok := fresh[id]; !ok {
+			diff.removed = append(diff.removed, id)
+		}
+	}
+
+	sortApps(diff.added, fresh, sortName)
+	sortApps(diff.removed, old, sortName)
+	sortApps(diff.changed, fresh, sortName)
+	return diff
+}
+
+func (d catalogDiff) String() string {
+	var parts []string
+	for _, p := range []struct {
+		count int
+		what  string
+	}{{len(d.added), "new"}, {len(d.changed), "updated"}, {len(d.removed), "removed"}} {
+		if p.count == 1 {
+			parts = append(parts, fmt.Sprintf("1 app %s", p.what))
+		} else if p.count > 1 {
+			parts = append(parts, fmt.Sprintf("%d apps %s", p.count, p.what))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// beginRefresh marks that the catalog is being reloaded, returning false if it already is.
+// It is used by both the refresh action and the update checker, and must be called on the main goroutine.
+func (w *welcome) beginRefresh() bool {
+	if w.refreshing {
+		return false
+	}
+
+	w.refreshing = true
+	return true
+}
+
+// refresh downloads the catalog and featured apps again and updates the window to match.
+func (w *welcome) refresh(win fyne.Window) {
+	if !w.beginRefresh() {
+		return
+	}
+	previous := w.status.Text
+	w.status.SetText("Refreshing the catalog...")
+
+	go func() {
+		cat, err := fetchAppList()
+		if cat.apps == nil {
+			fyne.Do(func() {
+				w.refreshing = false
+				w.status.SetText(previous)
+				dialog.ShowError(err, win)
+			})
+			return
+		}
+		reconcileInstalled(cat.apps)
+		featured := makeFeatured(cat.apps, w.selectApp)
+
+		fyne.Do(func() {
+			w.refreshing = false
+			diff := w.applyCatalog(cat)
+			if len(featured.Objects) > 0 {
+				w.featured.Layout, w.featured.Objects = featured.Layout, featured.Objects
+				w.featured.Refresh()
+			}
+			if cat.source != "" {
+				w.status.SetText(w.status.Text + ", " + diff.String())
+			}
+			if err != nil {
+				dialog.ShowError(err, win)
+			}
+		})
+	}()
+}
+
+// applyCatalog replaces the apps shown with a newly loaded catalog, keeping the current selection if
+// it is still listed. New apps are highlighted for the rest of the session.
+func (w *welcome) applyCatalog(cat catalog) catalogDiff {
+	diff := diffAppLists(w.apps, cat.apps)
+	for _, id := range diff.added {
+		w.added[id] = true
+	}
+
+	w.apps, w.allApps, w.warnings = cat.apps, cat.all, cat.warnings
+	w.setStatus(cat.source)
+	w.developerFilter.Options = append([]string{allDevelopers}, w.apps.developers()...)
+	w.developerFilter.Refresh()
+	w.refreshNodes()
+
+	switch {
+	case w.detail.Visible():
+		if a, ok := w.apps[w.shownApp.ID]; ok {
+			w.loadAppDetail(a)
+			break
+		}
+		w.tree.Select("featured")
+		w.showContent(w.featured)
+	case w.grid.Visible():
+		w.showCategory(w.category)
+	}
+	return diff
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAppLists(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := AppList{
+		"com.example.kept":    {ID: "com.example.kept", Name: "Kept", Version: "1.0", Date: date},
+		"com.example.updated": {ID: "com.example.updated", Name: "Updated", Version: "1.0", Date: date},
+		"com.example.removed": {ID: "com.example.removed", Name: "Removed"},
+	}
+	fresh := AppList{
+		"com.example.kept":    {ID: "com.example.kept", Name: "Kept", Version: "1.0", Date: date},
+		"com.example.updated": {ID: "com.example.updated", Name: "Updated", Version: "1.1", Date: date},
+		"com.example.b":       {ID: "com.example.b", Name: "Beta"},
+		"com.example.a":       {ID: "com.example.a", Name: "Alpha"},
+	}
+
+	diff := diffAppLists(old, fresh)
+	assert.Equal(t, []string{"com.example.a", "com.example.b"}, diff.added)
+	assert.Equal(t, []string{"com.example.removed"}, diff.removed)
+	assert.Equal(t, []string{"com.example.updated"}, diff.changed)
+	assert.Equal(t, "2 apps new, 1 app updated, 1 app removed", diff.String())
+
+	assert.Equal(t, "no changes", diffAppLists(fresh, fresh).String())
+}
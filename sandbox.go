@@ -0,0 +1,114 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	keySandbox      = "build.sandbox"
+	keySandboxCache = "build.isolatecache"
+)
+
+// sandboxKeep lists the environment variables that a sandboxed build can see,
+// everything else (tokens, agent sockets, cloud credentials) is removed.
+var sandboxKeep = []string{
+	"PATH", "Path", "LANG", "LC_ALL", "TERM", "USER", "LOGNAME", "SHELL",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy",
+	"GOROOT", "GOPROXY", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOSUMDB", "GOTOOLCHAIN", "GOFLAGS",
+	"CC", "CXX", "CGO_CFLAGS", "CGO_CXXFLAGS", "CGO_LDFLAGS", "PKG_CONFIG_PATH",
+	"DEVELOPER_DIR", "SDKROOT", "MACOSX_DEPLOYMENT_TARGET",
+	"SYSTEMROOT", "SystemRoot", "SYSTEMDRIVE", "COMSPEC", "PATHEXT", "WINDIR", "ProgramFiles", "ProgramData",
+	"DISPLAY", "WAYLAND_DISPLAY", "XDG_RUNTIME_DIR",
+}
+
+// sandbox is a throwaway workspace, with its own home, temp and (optionally) Go folders, that a
+// build process runs in. It gives the build a clean environment, but is not a security boundary
+// as the build still runs as the current user.
+type sandbox struct {
+	dir string
+	env []string
+}
+
+func newSandbox(isolateCache bool) (*sandbox, error) {
+	dir, err := os.MkdirTemp("", "fyne-apps-build-*")
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range []string{"home", "tmp", "go"} {
+		err = os.Mkdir(filepath.Join(dir, sub), 0700)
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, err
+		}
+	}
+
+	var goEnv map[string]string
+	if !isolateCache {
+		goEnv = sharedGoEnv()
+	}
+	return &sandbox{dir: dir, env: sandboxEnv(os.Environ(), dir, goEnv)}, nil
+}
+
+func (s *sandbox) close() {
+	// the module cache is read-only, so make it writable before removing
+	_ = filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			_ = os.Chmod(path, 0700)
+		}
+		return nil
+	})
+	_ = os.RemoveAll(s.dir)
+}
+
+// sandboxEnv filters an environment to the allowed variables and points home, temp and
+// Go locations into the workspace. Go locations in shared are used in place of isolated ones.
+func sandboxEnv(environ []string, dir string, shared map[string]string) []string {
+	var env []string
+	for _, item := range environ {
+		key := strings.SplitN(item, "=", 2)[0]
+		for _, keep := range sandboxKeep {
+			if key == keep {
+				env = append(env, item)
+				break
+			}
+		}
+	}
+
+	home, tmp := filepath.Join(dir, "home"), filepath.Join(dir, "tmp")
+	env = append(env, "HOME="+home, "USERPROFILE="+home, "XDG_CONFIG_HOME="+filepath.Join(home, ".config"),
+		"TMPDIR="+tmp, "TMP="+tmp, "TEMP="+tmp,
+		"GIT_TERMINAL_PROMPT=0", "GIT_CONFIG_NOSYSTEM=1", "GIT_SSH_COMMAND=false")
+
+	goPath := filepath.Join(dir, "go")
+	goEnv := map[string]string{
+		"GOPATH":     goPath,
+		"GOMODCACHE": filepath.Join(goPath, "pkg", "mod"),
+		"GOCACHE":    filepath.Join(dir, "cache"),
+		"GOENV":      "off",
+	}
+	for key, val := range shared {
+		goEnv[key] = val
+	}
+	for _, key := range []string{"GOPATH", "GOMODCACHE", "GOCACHE", "GOENV"} {
+		env = append(env, key+"="+goEnv[key])
+	}
+	return env
+}
+
+// sharedGoEnv returns the user's Go cache locations, so they can be reused by a sandboxed build.
+func sharedGoEnv() map[string]string {
+	out, err := exec.Command("go", "env", "GOPATH", "GOMODCACHE", "GOCACHE").Output()
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 3 {
+		return nil
+	}
+	return map[string]string{"GOPATH": lines[0], "GOMODCACHE": lines[1], "GOCACHE": lines[2]}
+}
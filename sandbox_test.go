@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxEnv(t *testing.T) {
+	dir := filepath.Join("tmp", "sandbox")
+	environ := []string{"PATH=/usr/bin", "HOME=/home/user", "SSH_AUTH_SOCK=/tmp/agent", "GITHUB_TOKEN=secret",
+		"GOPROXY=https://proxy.golang.org"}
+
+	env := sandboxEnv(environ, dir, nil)
+	assert.Contains(t, env, "PATH=/usr/bin")
+	assert.Contains(t, env, "GOPROXY=https://proxy.golang.org")
+	assert.Contains(t, env, "HOME="+filepath.Join(dir, "home"))
+	assert.Contains(t, env, "GOPATH="+filepath.Join(dir, "go"))
+	assert.Contains(t, env, "GOCACHE="+filepath.Join(dir, "cache"))
+	assert.NotContains(t, env, "HOME=/home/user")
+	assert.NotContains(t, env, "SSH_AUTH_SOCK=/tmp/agent")
+	assert.NotContains(t, env, "GITHUB_TOKEN=secret")
+
+	env = sandboxEnv(environ, dir, map[string]string{"GOCACHE": "/home/user/.cache/go-build"})
+	assert.Contains(t, env, "GOCACHE=/home/user/.cache/go-build")
+	assert.Contains(t, env, "GOPATH="+filepath.Join(dir, "go"))
+}
+
+func TestNewSandbox(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	s, err := newSandbox(true)
+	assert.Nil(t, err)
+	assert.DirExists(t, filepath.Join(s.dir, "home"))
+	assert.NotContains(t, s.env, "GITHUB_TOKEN=secret")
+	assert.Equal(t, "secret", os.Getenv("GITHUB_TOKEN"))
+
+	s.close()
+	assert.NoDirExists(t, s.dir)
+}
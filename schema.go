@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// catalogAPIVersion is the version of the apps.fyne.io API that the catalog is loaded from.
+	catalogAPIVersion = 1
+	// catalogSchemaMajor is the newest major version of the catalog format that we can read.
+	// Newer minor versions only add fields, so they can be read by ignoring what we don't know.
+	catalogSchemaMajor = 1
+)
+
+// catalogEnvelope is the versioned form of the catalog, the original format is a bare array of apps.
+type catalogEnvelope struct {
+	Schema string          `json:"schema"`
+	Apps   json.RawMessage `json:"apps"`
+}
+
+// schemaError reports a catalog in a format that is too new for this version of the app.
+type schemaError struct {
+	version string
+}
+
+func (s *schemaError) Error() string {
+	return "the app catalog uses a newer format (version " + s.version + "), please update this app to see the latest apps"
+}
+
+func catalogURL(base string) string {
+	return base + apiPath("list.json")
+}
+
+// apiPath returns the location of a file in the version of the API that we read.
+func apiPath(file string) string {
+	return fmt.Sprintf("/api/v%d/%s", catalogAPIVersion, file)
+}
+
+// decodeCatalog reads the app entries from either a bare array or a versioned envelope.
+// Each entry is left encoded so that a problem with one does not stop the others loading.
+func decodeCatalog(reader io.Reader) ([]json.RawMessage, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if debugMode {
+		for _, field := range unknownCatalogFields(data) {
+			log.Println("Catalog contains unknown field", field)
+		}
+	}
+
+	apps := data
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var env catalogEnvelope
+		err = json.Unmarshal(data, &env)
+		if err != nil {
+			return nil, err
+		}
+		err = checkSchema(env.Schema)
+		if err != nil {
+			return nil, err
+		}
+		apps = env.Apps
+	}
+
+	var list []json.RawMessage
+	err = json.Unmarshal(apps, &list)
+	return list, err
+}
+
+// checkSchema returns an error if a catalog version has a major number newer than we support.
+func checkSchema(version string) error {
+	major := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)[0]
+	num, err := strconv.Atoi(major)
+	if err != nil {
+		return fmt.Errorf("invalid catalog schema version %q", version)
+	}
+
+	if num > catalogSchemaMajor {
+		return &schemaError{version: version}
+	}
+	return nil
+}
+
+// unknownCatalogFields lists the keys in catalog data that do not match any field we read.
+// Each is given as a path starting with the app ID, such as "xyz.andy.beebui: source.branch".
+func unknownCatalogFields(data []byte) []string {
+	var raw interface{}
+	if json.Unmarshal(data, &raw) != nil {
+		return nil
+	}
+
+	var ret []string
+	if env, ok := raw.(map[string]interface{}); ok {
+		for key := range env {
+			if _, ok := jsonField(reflect.TypeOf(catalogEnvelope{}), key); !ok {
+				ret = append(ret, key)
+			}
+		}
+		raw = env["apps"]
+	}
+
+	apps, _ := raw.([]interface{})
+	for i, a := range apps {
+		name := strconv.Itoa(i)
+		if fields, ok := a.(map[string]interface{}); ok {
+			if id, ok := fields["id"].(string); ok && id != "" {
+				name = id
+			}
+		}
+
+		for _, field := range unknownFields(a, reflect.TypeOf(App{}), "") {
+			ret = append(ret, name+": "+field)
+		}
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+func unknownFields(value interface{}, t reflect.Type, path string) []string {
+	var ret []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+		for key, item := range v {
+			field, ok := jsonField(t, key)
+			if !ok {
+				ret = append(ret, path+key)
+				continue
+			}
+			ret = append(ret, unknownFields(item, field.Type, path+key+".")...)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice {
+			return nil
+		}
+		for i, item := range v {
+			ret = append(ret, unknownFields(item, t.Elem(), fmt.Sprintf("%s%d.", path, i))...)
+		}
+	}
+	return ret
+}
+
+// jsonField finds the struct field that encoding/json would decode a key into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" || f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
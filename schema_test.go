@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCatalog(t *testing.T) {
+	res, err := loadAppListFromTestData()
+	assert.Nil(t, err)
+	defer res.Close()
+	list, err := decodeCatalog(res)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(list))
+
+	list, err = decodeCatalog(strings.NewReader(`{"schema": "1.1", "apps": [{"id": "a", "name": "Bugs"}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, `{"id": "a", "name": "Bugs"}`, string(list[0]))
+
+	_, err = decodeCatalog(strings.NewReader(`{"schema": "2.0", "apps": {"a": {}}}`))
+	var tooNew *schemaError
+	assert.True(t, errors.As(err, &tooNew))
+
+	_, err = decodeCatalog(strings.NewReader(`{"apps": []}`))
+	assert.NotNil(t, err)
+}
+
+func TestCatalogURL(t *testing.T) {
+	assert.Equal(t, "https://apps.fyne.io/api/v1/list.json", catalogURL("https://apps.fyne.io"))
+}
+
+func TestUnknownCatalogFields(t *testing.T) {
+	res, err := loadAppListFromTestData()
+	assert.Nil(t, err)
+	defer res.Close()
+	data, err := io.ReadAll(res)
+	assert.Nil(t, err)
+	assert.Empty(t, unknownCatalogFields(data))
+
+	fields := unknownCatalogFields([]byte(`{"schema": "1.2", "mirrors": [], "apps": [
+		{"id": "a", "Name": "Bugs", "source": {"branch": "main"}, "screenshots": [{"alt": "Playing"}]}]}`))
+	assert.Equal(t, []string{"a: screenshots.0.alt", "a: source.branch", "mirrors"}, fields)
+}
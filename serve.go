@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// runServe hosts a catalog from a folder of app entries, in the same form as apps.fyne.io.
+// It returns the exit code for the command.
+func runServe(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "the address to listen on")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: apps serve [-addr host:port] [folder]")
+		fmt.Fprintln(flags.Output(), "The folder contains an apps folder of entries, an optional featured.json "+
+			"and any icons or screenshots that the entries refer to.")
+		flags.PrintDefaults()
+	}
+	if flags.Parse(args) != nil {
+		return 2
+	}
+	dir := "."
+	if flags.NArg() > 0 {
+		dir = flags.Arg(0)
+	}
+
+	log.Println("Serving catalog from", dir, "at http://"+*addr)
+	err := http.ListenAndServe(*addr, newCatalogServer(dir))
+	log.Println(err)
+	return 1
+}
+
+// catalogServer serves the app list and featured apps from a folder, along with any other files in it.
+type catalogServer struct {
+	dir string
+}
+
+func newCatalogServer(dir string) http.Handler {
+	s := &catalogServer{dir: dir}
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPath("list.json"), s.serveList)
+	mux.HandleFunc(apiPath("featured.json"), s.serveFeatured)
+	mux.Handle("/", http.FileServer(http.Dir(dir)))
+	return mux
+}
+
+// serveList reads the entries each time, so that changes are seen without a restart.
+// Relative links are resolved before sending, as older clients expect every link to be absolute.
+func (s *catalogServer) serveList(w http.ResponseWriter, r *http.Request) {
+	apps, warnings, err := loadEntries(filepath.Join(s.dir, "apps"))
+	if err != nil {
+		log.Println("Failed to read entries:", err)
+		http.Error(w, "failed to read the app entries", http.StatusInternalServerError)
+		return
+	}
+	for _, warn := range warnings {
+		if warn.Skipped {
+			log.Println("Not serving", warn)
+		}
+	}
+
+	source := &url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+	if r.TLS != nil {
+		source.Scheme = "https"
+	}
+	for i, a := range apps {
+		apps[i] = a.resolveLinks(source)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(apps)
+	if err != nil {
+		log.Println("Failed to send list:", err)
+	}
+}
+
+func (s *catalogServer) serveFeatured(w http.ResponseWriter, r *http.Request) {
+	path := filepath.Join(s.dir, "featured.json")
+	if _, err := os.Stat(path); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]\n"))
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// loadEntries reads each JSON file in a folder, which can hold a single entry or a list of them.
+func loadEntries(dir string) ([]App, []catalogWarning, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []json.RawMessage
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if isSingleEntry(data) {
+			entries = append(entries, data)
+			continue
+		}
+		var list []json.RawMessage
+		err = json.Unmarshal(data, &list)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		entries = append(entries, list...)
+	}
+
+	apps, warnings := validateEntries(entries, nil)
+	if apps == nil {
+		apps = []App{}
+	}
+	return apps, warnings, nil
+}
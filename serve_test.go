@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestStore(t *testing.T) string {
+	dir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "apps"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "icons"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "apps", "thing.json"), []byte(`{"id": "com.example.thing",
+  "name": "Thing", "icon": "/icons/thing.png", "category": "tools", "date": "2024-01-01T00:00:00Z",
+  "source": {"git": "https://example.com/thing.git", "package": "example.com/thing"}}`), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "apps", "more.json"), []byte(`[{"id": "com.example.other",
+  "name": "Other", "icon": "https://example.com/other.png", "date": "2024-01-01T00:00:00Z",
+  "source": {"git": "https://example.com/other.git", "package": "example.com/other"}}, {"id": "com.example.bad"}]`),
+		0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "icons", "thing.png"), []byte("png"), 0644))
+	return dir
+}
+
+func TestLoadEntries(t *testing.T) {
+	apps, warnings, err := loadEntries(filepath.Join(makeTestStore(t), "apps"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(apps))
+	assert.Equal(t, "com.example.other", apps[0].ID)
+	assert.Equal(t, "com.example.thing", apps[1].ID)
+	assert.Equal(t, "com.example.bad", warnings[0].ID)
+
+	_, _, err = loadEntries(filepath.Join(t.TempDir(), "missing"))
+	assert.NotNil(t, err)
+}
+
+func TestCatalogServer(t *testing.T) {
+	server := httptest.NewServer(newCatalogServer(makeTestStore(t)))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/v1/list.json")
+	assert.Nil(t, err)
+	list, err := parseAppList(res.Body)
+	res.Body.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, server.URL+"/icons/thing.png", list["com.example.thing"].Icon)
+	assert.Equal(t, "https://example.com/other.png", list["com.example.other"].Icon)
+
+	res, err = http.Get(server.URL + "/api/v1/featured.json")
+	assert.Nil(t, err)
+	featured, err := parseFeatured(res.Body)
+	res.Body.Close()
+	assert.Nil(t, err)
+	assert.Empty(t, featured)
+
+	res, err = http.Get(server.URL + "/icons/thing.png")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "png", string(data))
+}
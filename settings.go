@@ -0,0 +1,244 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	keyCatalogURL      = "catalog.url"
+	keyInstallTarget   = "install.target"
+	keyInstallDir      = "install.dir"
+	keyLocationPrefix  = "location."
+	installTargetSys   = "system"
+	installTargetUser  = "user"
+	installTargetOther = "custom"
+)
+
+const (
+	defaultCatalogURL = "https://apps.fyne.io"
+	envCatalogURL     = "FYNE_APPS_URL"
+)
+
+// catalogURLFlag is set from the command line and overrides the other ways of choosing a catalog.
+var catalogURLFlag string
+
+var installTargetNames = map[string]string{
+	installTargetSys:   "System (all users)",
+	installTargetUser:  "Current user only",
+	installTargetOther: "Custom folder",
+}
+
+// catalogBase returns the address of the catalog to load apps from, without a trailing slash.
+// It is taken from the command line, then the environment, then the settings.
+func catalogBase() string {
+	base := catalogURLFlag
+	if base == "" {
+		base = os.Getenv(envCatalogURL)
+	}
+	if base == "" {
+		base = fyne.CurrentApp().Preferences().String(keyCatalogURL)
+	}
+
+	return normalCatalogURL(base)
+}
+
+// normalCatalogURL tidies a catalog address for use as a base, an empty address means the default catalog.
+func normalCatalogURL(base string) string {
+	base = strings.TrimRight(strings.TrimSpace(base), "/")
+	if base == "" {
+		return defaultCatalogURL
+	}
+	return base
+}
+
+// systemInstallDir is where the fyne installer puts apps when no location is specified.
+func systemInstallDir() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "/Applications"
+	case "windows":
+		return os.Getenv("ProgramFiles")
+	default:
+		return filepath.Join("/usr", "local")
+	}
+}
+
+func userInstallDir() string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Applications")
+	case "windows":
+		return filepath.Join(os.Getenv("LOCALAPPDATA"), "Programs")
+	default:
+		return filepath.Join(home, ".local")
+	}
+}
+
+// chosenInstallDir returns the location that new installs should use, according to the settings.
+func chosenInstallDir() string {
+	prefs := fyne.CurrentApp().Preferences()
+	switch prefs.String(keyInstallTarget) {
+	case installTargetUser:
+		return userInstallDir()
+	case installTargetOther:
+		if dir := prefs.String(keyInstallDir); dir != "" {
+			return dir
+		}
+	}
+
+	return systemInstallDir()
+}
+
+// appInstallDir returns the location an app was installed to, or where it should be installed.
+func appInstallDir(a App) string {
+	dir := fyne.CurrentApp().Preferences().String(keyLocationPrefix + a.ID)
+	if dir != "" {
+		return dir
+	}
+
+	return chosenInstallDir()
+}
+
+func rememberInstallDir(a App, dir string) {
+	fyne.CurrentApp().Preferences().SetString(keyLocationPrefix+a.ID, dir)
+}
+
+func showSettings(win fyne.Window) {
+	prefs := fyne.CurrentApp().Preferences()
+	target := prefs.StringWithFallback(keyInstallTarget, installTargetSys)
+	custom := widget.NewEntry()
+	custom.SetText(prefs.String(keyInstallDir))
+	custom.SetPlaceHolder("Choose a folder")
+	browse := widget.NewButton("Browse...", func() {
+		dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err != nil || dir == nil {
+				return
+			}
+			custom.SetText(dir.Path())
+		}, win)
+	})
+	customRow := container.NewBorder(nil, nil, nil, browse, custom)
+
+	location := widget.NewLabel("")
+	options := []string{installTargetNames[installTargetSys], installTargetNames[installTargetUser],
+		installTargetNames[installTargetOther]}
+	choice := widget.NewRadioGroup(options, func(name string) {
+		for id, n := range installTargetNames {
+			if n == name {
+				target = id
+			}
+		}
+
+		switch target {
+		case installTargetUser:
+			location.SetText(userInstallDir())
+		case installTargetOther:
+			location.SetText("")
+		default:
+			location.SetText(systemInstallDir())
+		}
+		if target == installTargetOther {
+			customRow.Show()
+			location.Hide()
+		} else {
+			customRow.Hide()
+			location.Show()
+		}
+	})
+	choice.Required = true
+	choice.SetSelected(installTargetNames[target])
+
+	isolateCache := widget.NewCheck("Use a separate Go module and build cache", nil)
+	isolateCache.SetChecked(prefs.Bool(keySandboxCache))
+	sandbox := widget.NewCheck("Build in a clean environment", func(on bool) {
+		if on {
+			isolateCache.Enable()
+		} else {
+			isolateCache.Disable()
+		}
+	})
+	sandbox.SetChecked(prefs.BoolWithFallback(keySandbox, true))
+	if !sandbox.Checked {
+		isolateCache.Disable()
+	}
+
+	catalog := widget.NewEntry()
+	catalog.SetText(prefs.String(keyCatalogURL))
+	catalog.SetPlaceHolder(defaultCatalogURL)
+	catalog.Validator = func(text string) error {
+		if text == "" {
+			return nil
+		}
+		return checkURL(text)
+	}
+	catalogHint := "Used the next time the app starts"
+	if catalogURLFlag != "" || os.Getenv(envCatalogURL) != "" {
+		catalogHint = "Currently overridden by " + catalogBase()
+	}
+
+	mirrorList := widget.NewMultiLineEntry()
+	mirrorList.SetText(prefs.String(mirrorsKey(normalCatalogURL(catalog.Text))))
+	mirrorList.SetPlaceHolder("One address per line")
+	mirrorList.SetMinRowsVisible(2)
+	mirrorList.Validator = func(text string) error {
+		for _, m := range strings.Fields(text) {
+			if err := checkURL(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	catalog.OnChanged = func(text string) {
+		mirrorList.SetText(prefs.String(mirrorsKey(normalCatalogURL(text))))
+	}
+	mirrorHint := "Tried in order if this catalog cannot be reached"
+	if os.Getenv(envCatalogMirrors) != "" {
+		mirrorHint = "Currently overridden by " + envCatalogMirrors
+	}
+
+	background := widget.NewCheck("Check for updates in the background", nil)
+	background.SetChecked(prefs.Bool(keyBackgroundUpdates))
+
+	items := []*widget.FormItem{
+		{Text: "Install for", Widget: choice},
+		{Text: "Location", Widget: container.NewStack(location, customRow)},
+		{Text: "Builds", Widget: container.NewVBox(sandbox, isolateCache),
+			HintText: "Builds get their own home and temp folders and only common environment variables"},
+		{Text: "Catalog", Widget: catalog, HintText: catalogHint},
+		{Text: "Mirrors", Widget: mirrorList, HintText: mirrorHint},
+		{Text: "Updates", Widget: background,
+			HintText: "Keeps running in the system tray when closed, starting next time the app opens"},
+	}
+	dialog.ShowForm("Settings", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		if target == installTargetOther {
+			if err := checkWritable(custom.Text); err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			prefs.SetString(keyInstallDir, custom.Text)
+		}
+		prefs.SetString(keyInstallTarget, target)
+		prefs.SetBool(keySandbox, sandbox.Checked)
+		prefs.SetBool(keySandboxCache, isolateCache.Checked)
+		prefs.SetBool(keyBackgroundUpdates, background.Checked)
+		if text := strings.TrimSpace(catalog.Text); text == "" {
+			prefs.RemoveValue(keyCatalogURL)
+		} else {
+			prefs.SetString(keyCatalogURL, text)
+		}
+		prefs.SetString(mirrorsKey(normalCatalogURL(catalog.Text)), strings.Join(strings.Fields(mirrorList.Text), "\n"))
+	}, win)
+}
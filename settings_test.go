@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogBase(t *testing.T) {
+	test.NewTempApp(t)
+	t.Setenv(envCatalogURL, "")
+	assert.Equal(t, defaultCatalogURL, catalogBase())
+
+	fyne.CurrentApp().Preferences().SetString(keyCatalogURL, "")
+	assert.Equal(t, defaultCatalogURL, catalogBase())
+
+	fyne.CurrentApp().Preferences().SetString(keyCatalogURL, "https://staging.example.com/")
+	assert.Equal(t, "https://staging.example.com", catalogBase())
+
+	t.Setenv(envCatalogURL, "http://localhost:8080")
+	assert.Equal(t, "http://localhost:8080", catalogBase())
+
+	catalogURLFlag = "http://localhost:9000"
+	defer func() { catalogURLFlag = "" }()
+	assert.Equal(t, "http://localhost:9000", catalogBase())
+}
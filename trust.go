@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+const (
+	trustVerified   = "verified"
+	trustCommunity  = "community"
+	trustThirdParty = "third-party"
+	trustUnknown    = "unknown"
+)
+
+// trustLevel returns how far an app can be trusted, based on the catalog entry.
+// Apps with no level that are published by the Fyne project are treated as verified.
+func (a App) trustLevel() string {
+	switch strings.ToLower(strings.TrimSpace(a.Trust)) {
+	case trustVerified:
+		return trustVerified
+	case trustCommunity:
+		return trustCommunity
+	case trustThirdParty, "thirdparty", "third party":
+		return trustThirdParty
+	case "":
+		if strings.HasPrefix(a.Source.Package, "fyne.io/") ||
+			strings.HasPrefix(a.Source.Package, "github.com/fyne-io/") {
+			return trustVerified
+		}
+	}
+
+	return trustUnknown
+}
+
+// limitTrust ignores the trust levels given by a catalog other than the Fyne one, as any catalog
+// could claim that its apps are verified.
+func limitTrust(apps AppList, source *url.URL) {
+	if source != nil && strings.HasPrefix(source.String(), defaultCatalogURL+"/") {
+		return
+	}
+
+	for id, a := range apps {
+		a.Trust = trustUnknown
+		apps[id] = a
+	}
+}
+
+func trustDescription(level string) string {
+	switch level {
+	case trustVerified:
+		return "Verified by the Fyne team"
+	case trustCommunity:
+		return "Community reviewed"
+	case trustThirdParty:
+		return "Third-party source, not reviewed"
+	}
+
+	return "Unknown source"
+}
+
+func trustIcon(level string) fyne.Resource {
+	switch level {
+	case trustVerified:
+		return theme.ConfirmIcon()
+	case trustCommunity:
+		return theme.AccountIcon()
+	case trustThirdParty:
+		return theme.WarningIcon()
+	}
+
+	return theme.QuestionIcon()
+}
+
+// installSummary describes what will be downloaded and run when installing an app.
+func installSummary(a App) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s.\n\n", a.Name, trustDescription(a.trustLevel()))
+
+	if r := a.prebuilt(); r != nil {
+		fmt.Fprintf(&b, "A prebuilt package will be downloaded from:\n%s\n", r.URL)
+		if r.Size > 0 {
+			fmt.Fprintf(&b, "(%.1f MB, checked against its SHA-256 checksum)\n", float64(r.Size)/1024/1024)
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "The source code of the package:\n%s\n\n", a.Source.Package)
+	b.WriteString("will be downloaded from the repository that its import path points to")
+	switch {
+	case a.Source.Commit != "":
+		fmt.Fprintf(&b, " at commit %s", a.Source.Commit)
+	case a.Source.Version != "":
+		fmt.Fprintf(&b, " at version %s", a.Source.Version)
+	}
+	b.WriteString(" and built on this computer.\n")
+	b.WriteString("Building an app can run code from the project, only continue if you trust it.")
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_TrustLevel(t *testing.T) {
+	assert.Equal(t, trustVerified, App{Trust: "Verified"}.trustLevel())
+	assert.Equal(t, trustCommunity, App{Trust: "community"}.trustLevel())
+	assert.Equal(t, trustThirdParty, App{Trust: "third-party"}.trustLevel())
+	assert.Equal(t, trustUnknown, App{Trust: "trust me"}.trustLevel())
+	assert.Equal(t, trustUnknown, App{}.trustLevel())
+
+	fyneApp := App{Source: AppSource{Package: "github.com/fyne-io/examples/cmd/bugs"}}
+	assert.Equal(t, trustVerified, fyneApp.trustLevel())
+	fyneApp.Trust = trustCommunity
+	assert.Equal(t, trustCommunity, fyneApp.trustLevel())
+}
+
+func TestInstallSummary(t *testing.T) {
+	a := App{Name: "BeebUI", Source: AppSource{Git: "https://github.com/andydotxyz/beebui.git",
+		Package: "github.com/andydotxyz/beebui/cmd/beebui", Commit: "4f2d1c0"}}
+	summary := installSummary(a)
+	assert.Contains(t, summary, "BeebUI: Unknown source.")
+	assert.NotContains(t, summary, "https://github.com/andydotxyz/beebui.git")
+	assert.Contains(t, summary, "github.com/andydotxyz/beebui/cmd/beebui\n")
+	assert.Contains(t, summary, "points to at commit 4f2d1c0 and built")
+}
+
+func TestLimitTrust(t *testing.T) {
+	apps := AppList{"a": {ID: "a", Trust: trustVerified}, "b": {ID: "b",
+		Source: AppSource{Package: "fyne.io/apps"}}}
+	official, _ := url.Parse(defaultCatalogURL + "/api/v1/list.json")
+	limitTrust(apps, official)
+	assert.Equal(t, trustVerified, apps["a"].trustLevel())
+	assert.Equal(t, trustVerified, apps["b"].trustLevel())
+
+	other, _ := url.Parse("https://apps.example.com/api/v1/list.json")
+	limitTrust(apps, other)
+	assert.Equal(t, trustUnknown, apps["a"].trustLevel())
+	assert.Equal(t, trustUnknown, apps["b"].trustLevel())
+}
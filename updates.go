@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+const (
+	keyBackgroundUpdates = "updates.background"
+
+	updateCheckInterval = 6 * time.Hour
+)
+
+// newUpdates returns the upgrades available in the list that have not been reported before,
+// and remembers them in notified so that each version is only announced once.
+func newUpdates(list AppList, notified map[string]string) []App {
+	var ret []App
+	for id, a := range list {
+		if !hasUpdate(a) || notified[id] == a.Version {
+			continue
+		}
+
+		notified[id] = a.Version
+		ret = append(ret, a)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i].Name) < strings.ToLower(ret[j].Name)
+	})
+	return ret
+}
+
+func updateMessage(apps []App) string {
+	switch len(apps) {
+	case 0:
+		return "All apps are up to date"
+	case 1:
+		return fmt.Sprintf("%s %s is available", apps[0].Name, apps[0].Version)
+	case 2:
+		return fmt.Sprintf("%s and %s can be upgraded", apps[0].Name, apps[1].Name)
+	}
+
+	return fmt.Sprintf("%s, %s and %d other apps can be upgraded", apps[0].Name, apps[1].Name, len(apps)-2)
+}
+
+// startBackgroundUpdates adds a system tray menu and keeps checking for updates while the window is closed.
+func (w *welcome) startBackgroundUpdates(win fyne.Window) {
+	desk, ok := fyne.CurrentApp().(desktop.App)
+	if !ok {
+		return
+	}
+
+	check := make(chan struct{}, 1)
+	desk.SetSystemTrayMenu(fyne.NewMenu("Fyne Apps",
+		fyne.NewMenuItem("Show Updates", func() {
+			w.showUpdates(win)
+		}),
+		fyne.NewMenuItem("Check for Updates", func() {
+			select {
+			case check <- struct{}{}:
+			default: // a check is already waiting
+			}
+		}),
+		fyne.NewMenuItem("Open", func() {
+			win.Show()
+			win.RequestFocus()
+		})))
+	win.SetCloseIntercept(win.Hide)
+
+	notified := make(map[string]string)
+	notifyUpdates(newUpdates(w.apps, notified))
+	go w.runUpdateChecker(check, notified)
+}
+
+func (w *welcome) runUpdateChecker(check <-chan struct{}, notified map[string]string) {
+	tick := time.NewTicker(updateCheckInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+		case <-check:
+		}
+
+		w.checkForUpdates(notified)
+	}
+}
+
+// checkForUpdates refreshes the catalog and sends a notification if new upgrades are available.
+// If the catalog is already being refreshed then this check is skipped.
+func (w *welcome) checkForUpdates(notified map[string]string) {
+	started := false
+	fyne.DoAndWait(func() {
+		started = w.beginRefresh()
+	})
+	if !started {
+		return
+	}
+
+	cat, err := fetchAppList()
+	if cat.apps == nil {
+		fyne.LogError("Failed to check for updates", err)
+		fyne.Do(func() {
+			w.refreshing = false
+		})
+		return
+	}
+	reconcileInstalled(cat.apps)
+
+	fresh := newUpdates(cat.apps, notified)
+	fyne.Do(func() {
+		w.refreshing = false
+		w.applyCatalog(cat)
+	})
+	notifyUpdates(fresh)
+}
+
+func notifyUpdates(apps []App) {
+	if len(apps) > 0 {
+		fyne.CurrentApp().SendNotification(fyne.NewNotification("Updates available", updateMessage(apps)))
+	}
+}
+
+// showUpdates opens the window on the list of apps that can be upgraded.
+func (w *welcome) showUpdates(win fyne.Window) {
+	win.Show()
+	win.RequestFocus()
+
+	w.tree.OpenBranch(nodeUpdates)
+	w.tree.Select(nodeUpdates)
+	w.showCategory(nodeUpdates)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpdates(t *testing.T) {
+	test.NewTempApp(t)
+	list := AppList{
+		"a": App{ID: "a", Name: "Bugs", Version: "1.1"},
+		"b": App{ID: "b", Name: "Calculator", Version: "1.0"},
+		"c": App{ID: "c", Name: "BeebUI", Version: "2.0"},
+		"d": App{ID: "d", Name: "Dice", Version: "2.0"},
+		"e": App{ID: "e", Name: "Editor", Version: "1.0"},
+	}
+	setInstalledVersion(list["a"], "1.0")
+	markInstalled(list["b"])
+	setInstalledVersion(list["c"], "1.0")
+	setInstalledVersion(list["d"], versionUnknown)
+	setInstalledVersion(list["e"], versionLatest)
+
+	notified := make(map[string]string)
+	fresh := newUpdates(list, notified)
+	assert.Equal(t, 2, len(fresh))
+	assert.Equal(t, "BeebUI and Bugs can be upgraded", updateMessage(fresh))
+	assert.Empty(t, newUpdates(list, notified))
+
+	list["a"] = App{ID: "a", Name: "Bugs", Version: "1.2"}
+	fresh = newUpdates(list, notified)
+	assert.Equal(t, "Bugs 1.2 is available", updateMessage(fresh))
+}
+
+func TestUpdateMessage(t *testing.T) {
+	apps := []App{{Name: "A"}, {Name: "B"}, {Name: "C"}, {Name: "D"}}
+	assert.Equal(t, "A, B and 2 other apps can be upgraded", updateMessage(apps))
+	assert.Equal(t, "All apps are up to date", updateMessage(nil))
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// knownCategories are the categories used by the catalog, after aliases are applied.
+var knownCategories = []string{
+	"development", "education", "entertainment", "finance", "games", "graphics", "medical", "multimedia",
+	"network", "productivity", "scientific", "tools", "utility", categoryOther,
+}
+
+var warningHeaders = []string{"Entry", "App ID", "Field", "Problem"}
+
+// catalogWarning describes a problem with an entry in the catalog.
+// Entries that are Skipped are left out of the list, others are shown but may not work as expected.
+type catalogWarning struct {
+	Index   int
+	ID      string
+	Field   string
+	Message string
+	Skipped bool
+}
+
+func (c catalogWarning) String() string {
+	msg := fmt.Sprintf("entry %d", c.Index)
+	if c.ID != "" {
+		msg += " (" + c.ID + ")"
+	}
+	if c.Field != "" {
+		msg += " " + c.Field
+	}
+	msg += ": " + c.Message
+	if c.Skipped {
+		msg += ", skipped"
+	}
+	return msg
+}
+
+func (c catalogWarning) fields() []string {
+	problem := c.Message
+	if c.Skipped {
+		problem += " (skipped)"
+	}
+	return []string{fmt.Sprint(c.Index), c.ID, c.Field, problem}
+}
+
+// parseAppListWithWarnings reads the catalog, leaving out any entries that are not valid.
+// A problem with an entry is reported as a warning, only an unreadable catalog returns an error.
+// If the source address of the catalog is given then relative links are resolved against it.
+func parseAppListWithWarnings(reader io.Reader, source *url.URL) (AppList, []catalogWarning, error) {
+	appList, warnings, err := parseCatalog(reader, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	return appList.filterCompatible(), warnings, nil
+}
+
+// parseCatalog reads the valid entries of the catalog, including apps that are not for this platform.
+func parseCatalog(reader io.Reader, source *url.URL) (AppList, []catalogWarning, error) {
+	entries, err := decodeCatalog(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apps, warnings := validateEntries(entries, source)
+	appList := AppList{}
+	for _, a := range apps {
+		appList[a.ID] = a
+	}
+	return appList, warnings, nil
+}
+
+// validateEntries decodes each catalog entry, returning the valid apps in order and warnings for the rest.
+func validateEntries(entries []json.RawMessage, source *url.URL) ([]App, []catalogWarning) {
+	var apps []App
+	var warnings []catalogWarning
+	seen := make(map[string]bool)
+	for i, data := range entries {
+		var a App
+		err := json.Unmarshal(data, &a)
+		if err != nil {
+			var partial struct{ ID string }
+			_ = json.Unmarshal(data, &partial)
+			warnings = append(warnings, catalogWarning{Index: i, ID: partial.ID, Message: err.Error(), Skipped: true})
+			continue
+		}
+		if source != nil {
+			a = a.resolveLinks(source)
+		}
+
+		problems := a.validate()
+		if seen[a.ID] && a.ID != "" {
+			problems = append(problems, catalogWarning{Field: "id", Message: "duplicate of an earlier entry",
+				Skipped: true})
+		}
+
+		skip := false
+		for _, p := range problems {
+			p.Index, p.ID = i, a.ID
+			warnings = append(warnings, p)
+			skip = skip || p.Skipped
+		}
+		if !skip {
+			seen[a.ID] = true
+			apps = append(apps, a)
+		}
+	}
+
+	return apps, warnings
+}
+
+// validate checks an app entry, returning a warning for each problem found.
+func (a App) validate() []catalogWarning {
+	var ret []catalogWarning
+	required := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			ret = append(ret, catalogWarning{Field: field, Message: "missing required field", Skipped: true})
+		}
+	}
+	link := func(field, value string) {
+		if value == "" {
+			return
+		}
+		if err := checkURL(value); err != nil {
+			ret = append(ret, catalogWarning{Field: field, Message: err.Error()})
+		}
+	}
+
+	required("id", a.ID)
+	required("name", a.Name)
+	required("source.package", a.Source.Package)
+	link("icon", a.Icon)
+	link("url", a.URL)
+	link("website", a.Website)
+	link("source.git", a.Source.Git)
+	for i, s := range a.Screenshots {
+		link(fmt.Sprintf("screenshots.%d.image", i), s.Image)
+	}
+	for i, r := range a.Releases {
+		link(fmt.Sprintf("releases.%d.url", i), r.URL)
+	}
+
+	if a.Date.IsZero() {
+		ret = append(ret, catalogWarning{Field: "date", Message: "missing release date"})
+	}
+	if !isKnownCategory(a.category()) {
+		ret = append(ret, catalogWarning{Field: "category", Message: "unknown category " + a.Category})
+	}
+	return ret
+}
+
+func checkURL(link string) error {
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if !u.IsAbs() && u.Host == "" {
+		return nil // relative to the catalog, resolved when it is loaded
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL is not http or https: %s", link)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL has no host: %s", link)
+	}
+	return nil
+}
+
+func isKnownCategory(cat string) bool {
+	for _, c := range knownCategories {
+		if c == cat {
+			return true
+		}
+	}
+	return false
+}
+
+// showDiagnostics opens a window listing the problems found when loading the catalog.
+func showDiagnostics(warnings []catalogWarning) {
+	win := fyne.CurrentApp().NewWindow("Catalog Diagnostics")
+	if len(warnings) == 0 {
+		win.SetContent(container.NewCenter(widget.NewLabel("No problems were found in the app catalog")))
+		win.Resize(fyne.NewSize(400, 200))
+		win.Show()
+		return
+	}
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) {
+			return len(warnings), len(warningHeaders)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("io.fyne.example.app")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(warnings[id.Row].fields()[id.Col])
+		})
+	table.ShowHeaderColumn = false
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabel("Header")
+	}
+	table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+		if id.Row == -1 {
+			obj.(*widget.Label).SetText(warningHeaders[id.Col])
+		}
+	}
+	table.SetColumnWidth(3, 360)
+
+	summary := widget.NewLabel(fmt.Sprintf("%d problems were found in the app catalog", len(warnings)))
+	win.SetContent(container.NewBorder(summary, nil, nil, nil, table))
+	win.Resize(fyne.NewSize(760, 400))
+	win.Show()
+}
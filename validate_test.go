@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAppListWithWarnings(t *testing.T) {
+	res, err := loadAppListFromTestData()
+	assert.Nil(t, err)
+	defer res.Close()
+	list, warnings, err := parseAppListWithWarnings(res, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, len(list))
+	assert.Empty(t, warnings)
+}
+
+func TestParseAppListWithWarnings_Invalid(t *testing.T) {
+	res, err := os.Open(filepath.Join("testdata", "invalid.json"))
+	assert.Nil(t, err)
+	defer res.Close()
+	list, warnings, err := parseAppListWithWarnings(res, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, "Bugs", list["io.fyne.examples.bugs"].Name)
+	assert.Contains(t, list, "com.example.warnings")
+
+	type problem struct {
+		index     int
+		id, field string
+		skipped   bool
+	}
+	var got []problem
+	for _, w := range warnings {
+		got = append(got, problem{w.Index, w.ID, w.Field, w.Skipped})
+	}
+	assert.Equal(t, []problem{
+		{1, "", "id", true},
+		{2, "io.fyne.examples.bugs", "id", true},
+		{3, "com.example.baddate", "", true},
+		{4, "com.example.warnings", "website", false},
+		{4, "com.example.warnings", "screenshots.0.image", false},
+		{4, "com.example.warnings", "date", false},
+		{4, "com.example.warnings", "category", false},
+	}, got)
+	assert.Equal(t, "entry 2 (io.fyne.examples.bugs) id: duplicate of an earlier entry, skipped", warnings[1].String())
+}
+
+func TestParseAppListWithWarnings_Relative(t *testing.T) {
+	source, _ := url.Parse("https://example.com/store/api/v1/list.json")
+	list, warnings, err := parseAppListWithWarnings(strings.NewReader(`[{"id": "a", "name": "A",
+  "icon": "/icons/a.png", "url": "../../apps/a.html", "date": "2024-01-01T00:00:00Z",
+  "screenshots": [{"image": "shots/a.png"}], "source": {"package": "example.com/a"}}]`), source)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+
+	a := list["a"]
+	assert.Equal(t, "https://example.com/icons/a.png", a.Icon)
+	assert.Equal(t, "https://example.com/store/apps/a.html", a.URL)
+	assert.Equal(t, "https://example.com/store/api/v1/shots/a.png", a.Screenshots[0].Image)
+}
+
+func TestParseCatalog_Incompatible(t *testing.T) {
+	data := `[{"id": "a", "name": "A", "requires": "plan9", "source": {"package": "example.com/a"}}]`
+	all, _, err := parseCatalog(strings.NewReader(data), nil)
+	assert.Nil(t, err)
+	assert.Contains(t, all, "a")
+
+	list, _, err := parseAppListWithWarnings(strings.NewReader(data), nil)
+	assert.Nil(t, err)
+	assert.NotContains(t, list, "a")
+}
+
+func TestCheckURL(t *testing.T) {
+	assert.Nil(t, checkURL("https://apps.fyne.io/apps/bugs.html"))
+	assert.Nil(t, checkURL("icon.png"))
+	assert.Nil(t, checkURL("/icons/bugs.png"))
+	assert.NotNil(t, checkURL("ftp://example.com"))
+	assert.NotNil(t, checkURL("https://"))
+	assert.NotNil(t, checkURL("https://exa mple.com/%zz"))
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/zip"
+
+	//lint:ignore SA1019 this matches how the fyne getter finds the repository for a package
+	"golang.org/x/tools/go/vcs"
+)
+
+// securityError reports that what we were about to install is not what the catalog specified.
+type securityError struct {
+	msg string
+}
+
+func (s *securityError) Error() string {
+	return "security check failed: " + s.msg
+}
+
+// fetchedSource is a checkout of an app's source, which is verified and then built in place.
+type fetchedSource struct {
+	root   string // the repository checkout
+	dir    string // the package folder inside it
+	commit string
+}
+
+func (f *fetchedSource) close() {
+	_ = os.RemoveAll(f.root)
+}
+
+// fetchSource clones the repository that the app's package is in, at the version or commit pinned
+// by the catalog. It looks up the repository in the same way as "go get", so that it is the code
+// that the package path refers to.
+func fetchSource(a App) (*fetchedSource, error) {
+	repo, err := vcs.RepoRootForImportPath(a.Source.Package, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up source control for package: %w", err)
+	}
+	if repo.VCS.Name != "Git" {
+		return nil, errors.New("unsupported version control: " + repo.VCS.Name)
+	}
+
+	root, err := os.MkdirTemp("", "fyne-apps-source-*")
+	if err != nil {
+		return nil, err
+	}
+	commit, err := cloneSource(repo.Repo, root, a.Source)
+	if err != nil {
+		_ = os.RemoveAll(root)
+		return nil, err
+	}
+
+	sub := strings.TrimPrefix(strings.TrimPrefix(a.Source.Package, repo.Root), "/")
+	return &fetchedSource{root: root, dir: filepath.Join(root, filepath.FromSlash(sub)), commit: commit}, nil
+}
+
+// cloneSource checks out a repository into an empty folder, at the tag for the version if set or the
+// pinned commit, and returns the commit that was checked out.
+func cloneSource(repo, dir string, src AppSource) (string, error) {
+	args := []string{"clone", "--quiet"}
+	if src.Commit == "" {
+		args = append(args, "--depth=1")
+	}
+	if src.Version != "" {
+		args = append(args, "--branch", src.Version)
+	}
+	if out, err := exec.Command("git", append(args, repo, dir)...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to download %s: %s", repo, strings.TrimSpace(string(out)))
+	}
+
+	if src.Version != "" {
+		if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/tags/"+src.Version); err != nil {
+			return "", &securityError{msg: fmt.Sprintf("version %s of %s is not a tag", src.Version, repo)}
+		}
+	} else if src.Commit != "" {
+		if out, err := exec.Command("git", "-C", dir, "checkout", "--quiet", "--detach", src.Commit).CombinedOutput(); err != nil {
+			return "", &securityError{msg: fmt.Sprintf("commit %s is not in %s: %s", src.Commit, repo,
+				strings.TrimSpace(string(out)))}
+		}
+	}
+	return gitOutput(dir, "rev-parse", "HEAD")
+}
+
+// verifySource checks that the fetched source matches any commit or module sum pinned by the catalog.
+func verifySource(a App, src *fetchedSource) error {
+	pin := a.Source
+	if pin.Commit != "" && !commitMatches(src.commit, pin.Commit) {
+		return &securityError{msg: fmt.Sprintf("%s is at commit %s but the catalog requires %s",
+			pin.Package, src.commit, pin.Commit)}
+	}
+	if pin.Sum != "" {
+		if pin.Version == "" {
+			return &securityError{msg: "the catalog provides a module sum without a version"}
+		}
+		sum, err := sourceSum(src, pin.Version)
+		if err != nil {
+			return &securityError{msg: "unable to verify module sum: " + err.Error()}
+		}
+		if sum != pin.Sum {
+			return &securityError{msg: fmt.Sprintf("module %s@%s has sum %s but the catalog requires %s",
+				pin.Package, pin.Version, sum, pin.Sum)}
+		}
+	}
+
+	return nil
+}
+
+func commitMatches(commit, pinned string) bool {
+	return len(pinned) >= 7 && strings.HasPrefix(strings.ToLower(commit), strings.ToLower(pinned))
+}
+
+// sourceSum returns the go.sum hash of the module containing the fetched package. The module is zipped
+// in the same way as the Go command does, so the hash matches the one in go.sum files.
+func sourceSum(src *fetchedSource, version string) (string, error) {
+	modDir := src.dir
+	for {
+		if _, err := os.Stat(filepath.Join(modDir, "go.mod")); err == nil {
+			break
+		}
+		if modDir == src.root || filepath.Dir(modDir) == modDir {
+			return "", errors.New("no go.mod found for the package")
+		}
+		modDir = filepath.Dir(modDir)
+	}
+	data, err := os.ReadFile(filepath.Join(modDir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp("", "fyne-apps-module-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	err = zip.CreateFromDir(tmp, module.Version{Path: modfile.ModulePath(data), Version: version}, modDir)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return dirhash.HashZip(tmp.Name(), dirhash.Hash1)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitMatches(t *testing.T) {
+	commit := "4f2d1c0b9e8a7d6c5b4a39281706f5e4d3c2b1a0"
+	assert.True(t, commitMatches(commit, commit))
+	assert.True(t, commitMatches(commit, "4F2D1C0"))
+	assert.False(t, commitMatches(commit, "4f2d"))
+	assert.False(t, commitMatches(commit, "0000000"))
+	assert.False(t, commitMatches("", "4f2d1c0"))
+}
+
+func TestCloneSource(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c",
+			"user.email=test@example.com"}, args...)...).CombinedOutput()
+		assert.Nil(t, err, string(out))
+		return string(out)
+	}
+	git("init", "--quiet")
+	assert.Nil(t, os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module example.com/app\n"), 0644))
+	git("add", "go.mod")
+	git("commit", "--quiet", "-m", "first")
+	git("tag", "v1.0.0")
+	first, _ := gitOutput(repo, "rev-parse", "HEAD")
+	assert.Nil(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0644))
+	git("add", "main.go")
+	git("commit", "--quiet", "-m", "second")
+	second, _ := gitOutput(repo, "rev-parse", "HEAD")
+
+	url := "file://" + filepath.ToSlash(repo)
+	commit, err := cloneSource(url, filepath.Join(t.TempDir(), "head"), AppSource{})
+	assert.Nil(t, err)
+	assert.Equal(t, second, commit)
+
+	commit, err = cloneSource(url, filepath.Join(t.TempDir(), "tag"), AppSource{Version: "v1.0.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, first, commit)
+
+	commit, err = cloneSource(url, filepath.Join(t.TempDir(), "commit"), AppSource{Commit: first[:8]})
+	assert.Nil(t, err)
+	assert.Equal(t, first, commit)
+
+	_, err = cloneSource(url, filepath.Join(t.TempDir(), "missing"), AppSource{Commit: "00000000"})
+	assert.NotNil(t, err)
+}
+
+func TestVerifySource(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	src := &fetchedSource{root: dir, dir: dir, commit: "4f2d1c0b9e8a7d6c5b4a39281706f5e4d3c2b1a0"}
+
+	assert.Nil(t, verifySource(App{}, src))
+	assert.Nil(t, verifySource(App{Source: AppSource{Commit: "4f2d1c0b"}}, src))
+	assert.NotNil(t, verifySource(App{Source: AppSource{Commit: "00000000"}}, src))
+
+	sum, err := sourceSum(src, "v1.0.0")
+	assert.Nil(t, err)
+	pinned := App{Source: AppSource{Package: "example.com/app", Version: "v1.0.0", Sum: sum}}
+	assert.Nil(t, verifySource(pinned, src))
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main // changed\n"), 0644))
+	var secErr *securityError
+	assert.ErrorAs(t, verifySource(pinned, src), &secErr)
+}
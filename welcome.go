@@ -6,17 +6,13 @@ import (
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
-	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/cmd/fyne/commands"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
@@ -27,13 +23,31 @@ import (
 type welcome struct {
 	shownApp            App
 	name, summary, date *widget.Label
-	developer, version  *widget.Label
-	link                *widget.Hyperlink
+	version             *widget.Label
+	developer, link     *widget.Hyperlink
 	icon                *canvas.Image
+	trust               *widget.Label
+	trustIcon           *widget.Icon
 
 	screenshots  [5]*canvas.Image
 	screenScroll *container.Scroll
 	install      *widget.Button
+	rollback     *widget.Button
+	favorite     *widget.Button
+
+	apps, allApps                   AppList
+	warnings                        []catalogWarning
+	status                          *widget.Label
+	added                           map[string]bool
+	refreshing                      bool
+	nodes                           map[string][]string
+	tree                            *widget.Tree
+	featured, detail, grid, devPage *fyne.Container
+	category, order, view           string
+
+	search          *widget.Entry
+	developerFilter *widget.Select
+	query, filterBy string
 }
 
 func (w *welcome) loadAppDetail(app App) {
@@ -44,6 +58,10 @@ func (w *welcome) loadAppDetail(app App) {
 	w.version.SetText(app.Version)
 	w.date.SetText(app.Date.Format("02 Jan 2006"))
 	w.summary.SetText(app.Summary)
+	trust := app.trustLevel()
+	w.trust.SetText(trustDescription(trust))
+	w.trustIcon.SetResource(trustIcon(trust))
+	w.updateFavorite()
 
 	w.icon.Resource = nil
 	w.icon.Image = nil
@@ -84,6 +102,95 @@ func (w *welcome) loadAppDetail(app App) {
 		w.install.SetText("Upgrade")
 		w.install.Enable()
 	}
+
+	if len(previousBuilds(app)) > 0 {
+		w.rollback.Show()
+	} else {
+		w.rollback.Hide()
+	}
+}
+
+// confirmInstall asks the user to check what will be downloaded and built, unless the app is verified.
+func (w *welcome) confirmInstall(win fyne.Window) {
+	confirmTrust([]App{w.shownApp}, win, func() {
+		w.installApp(win)
+	})
+}
+
+// confirmTrust asks the user to check what will be downloaded and built for each of the apps that
+// are not verified, calling install if they agree or if all of the apps are verified.
+func confirmTrust(apps []App, win fyne.Window, install func()) {
+	var summaries []string
+	for _, a := range apps {
+		if a.trustLevel() != trustVerified {
+			summaries = append(summaries, installSummary(a))
+		}
+	}
+	if len(summaries) == 0 {
+		install()
+		return
+	}
+
+	title := "Install " + apps[0].Name + "?"
+	if len(apps) > 1 {
+		title = fmt.Sprintf("Install %d unverified apps?", len(summaries))
+	}
+	summary := widget.NewLabel(strings.Join(summaries, "\n\n"))
+	summary.Wrapping = fyne.TextWrapWord
+	confirm := dialog.NewCustomConfirm(title, "Install", "Cancel", container.NewVScroll(summary),
+		func(ok bool) {
+			if ok {
+				install()
+			}
+		}, win)
+	confirm.Resize(fyne.NewSize(480, 320))
+	confirm.Show()
+}
+
+func (w *welcome) installApp(win fyne.Window) {
+	bar := widget.NewProgressBarInfinite()
+	content := container.NewVBox(widget.NewLabel("Please wait while the app is installed"), bar)
+	prog := dialog.NewCustomWithoutButtons("Downloading...", content, win)
+	bar.Start()
+	prog.Show()
+	err := installApp(w.shownApp)
+	prog.Hide()
+	bar.Stop()
+	if err != nil {
+		dialog.ShowError(err, win)
+	} else {
+		dialog.ShowInformation("Installed", "App was installed successfully :)", win)
+		w.loadAppDetail(w.shownApp)
+		w.updateVirtualNodes()
+	}
+}
+
+func (w *welcome) rollbackApp(win fyne.Window) {
+	builds := previousBuilds(w.shownApp)
+	if len(builds) == 0 {
+		return
+	}
+
+	labels := make([]string, len(builds))
+	for i, b := range builds {
+		labels[i] = b.String()
+	}
+	choice := widget.NewSelect(labels, nil)
+	choice.SetSelectedIndex(0)
+	dialog.ShowForm("Roll back "+w.shownApp.Name, "Roll back", "Cancel",
+		[]*widget.FormItem{{Text: "Version", Widget: choice}}, func(ok bool) {
+			if !ok || choice.SelectedIndex() < 0 {
+				return
+			}
+
+			err := restoreBuild(w.shownApp, builds[choice.SelectedIndex()])
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			w.loadAppDetail(w.shownApp)
+			w.updateVirtualNodes()
+		}, win)
 }
 
 func setImageFromURL(img *canvas.Image, location string) {
@@ -107,7 +214,22 @@ func setImageFromURL(img *canvas.Image, location string) {
 	})
 }
 
-func loadImageFromURL(urlStr string) (image.Image, error) {
+// loadImageFromURL downloads an image, trying the same path on each mirror if it is on the catalog.
+func loadImageFromURL(urlStr string) (img image.Image, err error) {
+	for _, link := range mirrorURLs(urlStr) {
+		img, err = loadImage(link)
+		if err == nil {
+			return img, nil
+		}
+
+		if base := mirrorBase(link); base != "" {
+			mirrors.failed(base)
+		}
+	}
+	return nil, err
+}
+
+func loadImage(urlStr string) (image.Image, error) {
 	res, err := http.Get(urlStr)
 	if err != nil {
 		return nil, err
@@ -137,10 +259,16 @@ func (i *iconHoverLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
 	return i.content.MinSize()
 }
 
-func loadUI(apps AppList, win fyne.Window) fyne.CanvasObject {
-	w := &welcome{}
+func loadUI(cat catalog, win fyne.Window, links <-chan string) fyne.CanvasObject {
+	apps := cat.apps
+	reconcileInstalled(apps)
+
+	w := &welcome{added: make(map[string]bool)}
 	w.name = widget.NewLabel("")
-	w.developer = widget.NewLabel("")
+	w.developer = widget.NewHyperlink("", nil)
+	w.developer.OnTapped = func() {
+		w.showDeveloper(w.shownApp.Developer)
+	}
 	w.link = widget.NewHyperlink("", nil)
 	w.summary = widget.NewLabel("")
 	w.summary.Wrapping = fyne.TextWrapWord
@@ -148,6 +276,8 @@ func loadUI(apps AppList, win fyne.Window) fyne.CanvasObject {
 	w.date = widget.NewLabel("")
 	w.icon = &canvas.Image{}
 	w.icon.FillMode = canvas.ImageFillContain
+	w.trust = widget.NewLabel("")
+	w.trustIcon = widget.NewIcon(nil)
 	makeScreenshots(w)
 
 	dateAndVersion := container.NewGridWithColumns(2, w.date,
@@ -156,103 +286,26 @@ func loadUI(apps AppList, win fyne.Window) fyne.CanvasObject {
 	form := widget.NewForm(
 		&widget.FormItem{Text: "Name", Widget: w.name},
 		&widget.FormItem{Text: "Developer", Widget: w.developer},
+		&widget.FormItem{Text: "Trust", Widget: container.NewHBox(w.trustIcon, w.trust)},
 		&widget.FormItem{Text: "Website", Widget: w.link},
 		&widget.FormItem{Text: "Summary", Widget: w.summary},
 		&widget.FormItem{Text: "Date", Widget: dateAndVersion},
 	)
 	details := container.New(&iconHoverLayout{content: form, icon: w.icon}, form, w.icon)
 
-	var app, featured *fyne.Container
-	nodes := mapAppList(apps)
-	tree := widget.NewTree(
-		func(id widget.TreeNodeID) []widget.TreeNodeID {
-			return nodes[id]
-		},
-		func(id widget.TreeNodeID) bool {
-			if id == "" {
-				return true
-			}
-			if id == "featured" {
-				return false
-			}
-
-			for _, n := range nodes[""] {
-				if n == id {
-					return true
-				}
-			}
-
-			return false
-		},
-		func(_ bool) fyne.CanvasObject {
-			return widget.NewLabel(" ->  A longish app name")
-		},
-		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
-			if id == "featured" {
-				obj.(*widget.Label).SetText("Featured")
-				return
-			}
-			if branch {
-				title := id
-				title = strings.ToUpper(id[:1]) + title[1:]
-
-				obj.(*widget.Label).SetText(title)
-				return
-			}
-
-			obj.(*widget.Label).SetText(apps[id].Name)
-		})
-	selectApp := func(id string) {
-		selected := apps[id]
-		tree.OpenBranch(selected.Category)
-		tree.Select(id)
-
-		app.Show()
-		featured.Hide()
-		w.loadAppDetail(selected)
-	}
-	tree.Select("featured")
-	tree.OnSelected = func(id widget.TreeNodeID) {
-		if id == "featured" {
-			featured.Show()
-			app.Hide()
-			return
-		}
-
-		for _, n := range nodes[""] {
-			if n == id {
-				tree.OpenBranch(id)
-				return
-			}
-		}
-
-		selectApp(id)
-	}
-
 	w.install = widget.NewButton("Install", func() {
-		bar := widget.NewProgressBarInfinite()
-		content := container.NewVBox(widget.NewLabel("Please wait while the app is installed"), bar)
-		prog := dialog.NewCustomWithoutButtons("Downloading...", content, win)
-		bar.Start()
-		prog.Show()
-		get := commands.NewGetter()
-		tmpIcon := downloadIcon(w.shownApp.Icon)
-		get.SetIcon(tmpIcon)
-		get.SetAppID(w.shownApp.ID)
-		err := get.Get(w.shownApp.Source.Package)
-		prog.Hide()
-		bar.Stop()
-		if err != nil {
-			dialog.ShowError(err, win)
-		} else {
-			dialog.ShowInformation("Installed", "App was installed successfully :)", win)
-			markInstalled(w.shownApp)
-			w.loadAppDetail(w.shownApp)
-		}
-		_ = os.Remove(tmpIcon)
+		w.confirmInstall(win)
 	})
+	w.rollback = widget.NewButton("Roll back", func() {
+		w.rollbackApp(win)
+	})
+	w.rollback.Hide()
+	w.favorite = widget.NewButtonWithIcon("", notFavoriteIcon, w.toggleFavorite)
+	w.favorite.Importance = widget.LowImportance
 	buttons := container.NewHBox(
+		w.favorite,
 		layout.NewSpacer(),
+		w.rollback,
 		w.install,
 	)
 
@@ -260,12 +313,94 @@ func loadUI(apps AppList, win fyne.Window) fyne.CanvasObject {
 		w.screenshots[0], w.screenshots[1], w.screenshots[2], w.screenshots[3], w.screenshots[4]))
 
 	content := container.NewBorder(details, nil, nil, nil, w.screenScroll)
-	app = container.NewBorder(nil, buttons, nil, nil, content)
-	featured = makeFeatured(apps, selectApp)
+	w.detail = container.NewBorder(nil, buttons, nil, nil, content)
+	w.featured = makeFeatured(apps, w.selectApp)
+	w.grid = container.NewStack()
+	w.devPage = container.NewStack()
+
+	prefs := fyne.CurrentApp().Preferences()
+	w.apps, w.allApps, w.warnings = apps, cat.all, cat.warnings
+	w.status = widget.NewLabel("")
+	w.status.Importance = widget.LowImportance
+	w.setStatus(cat.source)
+	w.order = prefs.StringWithFallback(keySortOrder, sortName)
+	w.view = prefs.StringWithFallback(keyViewMode, viewList)
+	w.refreshNodes()
+	tree := w.makeTree()
+	controls := w.makeBrowseControls(win)
+
+	w.detail.Hide()
+	w.grid.Hide()
+	w.devPage.Hide()
+	win.SetMainMenu(w.makeMenu(win))
+	win.Canvas().AddShortcut(refreshShortcut, func(fyne.Shortcut) {
+		w.refresh(win)
+	})
+	if prefs.Bool(keyBackgroundUpdates) {
+		w.startBackgroundUpdates(win)
+	}
+	go func() {
+		for id := range links {
+			link := id
+			fyne.Do(func() {
+				w.openLink(link, win)
+			})
+		}
+	}()
+	return container.NewBorder(nil, w.status, container.NewBorder(controls, nil, nil, nil, tree), nil,
+		container.NewStack(w.featured, w.detail, w.grid, w.devPage))
+}
 
-	app.Hide()
-	return container.NewBorder(nil, nil, tree, nil,
-		container.NewStack(featured, app))
+// setStatus shows where the catalog was loaded from, an empty source means it was read from the cache.
+func (w *welcome) setStatus(source string) {
+	if source == "" {
+		w.status.SetText("Offline, showing the catalog from the last download")
+		return
+	}
+
+	host := source
+	if u, err := url.Parse(source); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	if mirrorBase(source) == catalogBase() {
+		w.status.SetText("Catalog from " + host)
+	} else {
+		w.status.SetText("Catalog from mirror " + host)
+	}
+}
+
+func (w *welcome) makeMenu(win fyne.Window) *fyne.MainMenu {
+	refresh := fyne.NewMenuItem("Refresh Catalog", func() {
+		w.refresh(win)
+	})
+	refresh.Shortcut = refreshShortcut
+
+	return fyne.NewMainMenu(
+		fyne.NewMenu("File",
+			refresh,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Install History...", showHistory),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Import Favorites...", func() {
+				w.importFavorites(win)
+			}),
+			fyne.NewMenuItem("Export Favorites...", func() {
+				w.exportFavorites(win)
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Import Installed Apps...", func() {
+				w.importManifest(win)
+			}),
+			fyne.NewMenuItem("Export Installed Apps...", func() {
+				w.exportManifest(win)
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Catalog Diagnostics...", func() {
+				showDiagnostics(w.warnings)
+			}),
+			fyne.NewMenuItem("Settings...", func() {
+				showSettings(win)
+			})))
 }
 
 func makeScreenshots(w *welcome) {
@@ -278,15 +413,11 @@ func makeScreenshots(w *welcome) {
 	}
 }
 
-func mapAppList(list AppList) map[string][]string {
+func mapAppList(list AppList, order string) map[string][]string {
 	ret := make(map[string][]string)
 
 	for id, a := range list {
-		cat := a.Category
-		if cat == "" {
-			continue
-		}
-
+		cat := a.category()
 		similar, ok := ret[cat]
 		if !ok {
 			similar = []string{}
@@ -298,38 +429,16 @@ func mapAppList(list AppList) map[string][]string {
 
 	var cats []string
 	for cat, ids := range ret {
-		sort.Slice(ids, func(i, j int) bool {
-			return strings.Compare(strings.ToLower(list[ids[i]].Name), strings.ToLower(list[ids[j]].Name)) < 0
-		})
-
+		sortApps(ids, list, order)
 		cats = append(cats, cat)
 	}
 
 	sort.Slice(cats, func(i, j int) bool {
+		if cats[i] == categoryOther || cats[j] == categoryOther {
+			return cats[j] == categoryOther && cats[i] != categoryOther
+		}
 		return strings.Compare(cats[i], cats[j]) < 0
 	})
 	ret[""] = append([]string{"featured"}, cats...)
 	return ret
 }
-
-func downloadIcon(url string) string {
-	req, err := http.Get(url)
-	if err != nil {
-		fyne.LogError("Failed to access icon url: "+url, err)
-		return ""
-	}
-	tmp := filepath.Join(os.TempDir(), "Fyne-Icon.png")
-	data, err := io.ReadAll(req.Body)
-	if err != nil {
-		fyne.LogError("Failed tread icon data", err)
-		return ""
-	}
-
-	err = os.WriteFile(tmp, data, 0666)
-	if err != nil {
-		fyne.LogError("Failed to get write icon to: "+tmp, err)
-		return ""
-	}
-
-	return tmp
-}